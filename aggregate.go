@@ -0,0 +1,59 @@
+package robin
+
+// Sum returns the sum of the values in the robin, without advancing
+// it. It is O(n).
+func Sum[T Numeric](r *Robin[T]) T {
+	var sum T
+	for v := range r.nodes {
+		sum += v
+	}
+	return sum
+}
+
+// Min returns the smallest value in the robin, without advancing it.
+// If the robin is empty, the second return value is false. It is O(n).
+func Min[T Ordered](r *Robin[T]) (T, bool) {
+	var (
+		min T
+		ok  bool
+	)
+	for v := range r.nodes {
+		if !ok || v < min {
+			min, ok = v, true
+		}
+	}
+	return min, ok
+}
+
+// Max returns the largest value in the robin, without advancing it.
+// If the robin is empty, the second return value is false. It is O(n).
+func Max[T Ordered](r *Robin[T]) (T, bool) {
+	var (
+		max T
+		ok  bool
+	)
+	for v := range r.nodes {
+		if !ok || v > max {
+			max, ok = v, true
+		}
+	}
+	return max, ok
+}
+
+// Reduce folds the values in the robin into an accumulator, starting
+// from init and calling fn with each value in rotation order from the
+// current position, without advancing the robin. It is O(n).
+func Reduce[T comparable, A any](r *Robin[T], init A, fn func(A, T) A) A {
+	acc := init
+	if r.next == nil {
+		return acc
+	}
+	node := r.next
+	for {
+		acc = fn(acc, node.v)
+		node = r.step(node)
+		if node == r.next {
+			return acc
+		}
+	}
+}
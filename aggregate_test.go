@@ -0,0 +1,58 @@
+package robin_test
+
+import (
+	"testing"
+
+	"github.com/embeage/robin"
+)
+
+func TestAggregates(t *testing.T) {
+	r := robin.NewUnbounded[int]()
+	if got := robin.Sum(r); got != 0 {
+		t.Errorf("Sum on empty robin got %v, want 0", got)
+	}
+	if _, ok := robin.Min(r); ok {
+		t.Errorf("Min on empty robin returned true")
+	}
+	if _, ok := robin.Max(r); ok {
+		t.Errorf("Max on empty robin returned true")
+	}
+
+	r.Add(4, -2, 9, 3)
+	r.Next()
+	if got, want := robin.Sum(r), 14; got != want {
+		t.Errorf("Sum got %v, want %v", got, want)
+	}
+	if got, _ := robin.Min(r); got != -2 {
+		t.Errorf("Min got %v, want -2", got)
+	}
+	if got, _ := robin.Max(r); got != 9 {
+		t.Errorf("Max got %v, want 9", got)
+	}
+	if v, _ := r.Next(); v != -2 {
+		t.Errorf("aggregates should not advance robin, Next got %v, want -2", v)
+	}
+}
+
+func TestReduce(t *testing.T) {
+	s := robin.NewUnbounded[string]()
+	concat := func(acc string, v string) string { return acc + v }
+	if got := robin.Reduce(s, "", concat); got != "" {
+		t.Errorf("Reduce on empty robin got %q, want %q", got, "")
+	}
+
+	s.Add("a", "b", "c")
+	s.Next()
+	if got, want := robin.Reduce(s, ">", concat), ">bca"; got != want {
+		t.Errorf("Reduce got %q, want %q", got, want)
+	}
+	if v, _ := s.Next(); v != "b" {
+		t.Errorf("Reduce should not advance robin, Next got %q, want %q", v, "b")
+	}
+
+	r := robin.NewUnbounded[int]()
+	r.Add(1, 2, 3, 4)
+	if got, want := robin.Reduce(r, 0, func(acc, v int) int { return acc + v }), 10; got != want {
+		t.Errorf("Reduce got %v, want %v", got, want)
+	}
+}
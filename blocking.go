@@ -0,0 +1,132 @@
+package robin
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// BlockingRobin wraps a [Robin] with a mutex, making it safe for
+// concurrent use, and adds [BlockingRobin.NextWait] which waits for
+// a value to be added to an empty robin instead of returning false.
+//
+// The wrapped robin must not be used directly once it is wrapped.
+type BlockingRobin[T comparable] struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+	r    *Robin[T]
+}
+
+// NewBlockingRobin wraps the robin in a new [BlockingRobin].
+func NewBlockingRobin[T comparable](r *Robin[T]) *BlockingRobin[T] {
+	b := &BlockingRobin[T]{r: r}
+	b.cond = sync.NewCond(&b.mu)
+	return b
+}
+
+// Add values to the robin and wake up any waiting callers of
+// [BlockingRobin.NextWait]. See [Robin.Add].
+func (b *BlockingRobin[T]) Add(vs ...T) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.r.Add(vs...)
+	b.cond.Broadcast()
+}
+
+// Remove values from the robin. See [Robin.Remove].
+func (b *BlockingRobin[T]) Remove(vs ...T) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.r.Remove(vs...)
+}
+
+// Next returns the next value in the robin without waiting. See
+// [Robin.Next].
+func (b *BlockingRobin[T]) Next() (T, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.r.Next()
+}
+
+// NextWait returns the next value in the robin, waiting until a value
+// is added if the robin is empty. If ctx is done before a value is
+// available, the second return value is false.
+func (b *BlockingRobin[T]) NextWait(ctx context.Context) (T, bool) {
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			b.mu.Lock()
+			b.cond.Broadcast()
+			b.mu.Unlock()
+		case <-done:
+		}
+	}()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for b.r.Len() == 0 {
+		if ctx.Err() != nil {
+			return *new(T), false
+		}
+		b.cond.Wait()
+	}
+	return b.r.Next()
+}
+
+// Ticker starts a goroutine that advances the robin every d and sends
+// each returned value on the returned channel. Ticks where the robin is
+// empty are skipped. The returned function stops the ticker and waits
+// for the goroutine to exit, after which the channel is closed. It is
+// safe to call more than once. Ticker panics if d is not positive.
+func (b *BlockingRobin[T]) Ticker(d time.Duration) (<-chan T, func()) {
+	if d <= 0 {
+		panic("robin: non-positive interval for Ticker")
+	}
+	t := time.NewTicker(d)
+	c := make(chan T)
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer close(c)
+		defer t.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-t.C:
+			}
+			v, ok := b.Next()
+			if !ok {
+				continue
+			}
+			select {
+			case c <- v:
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return c, func() {
+		once.Do(func() { close(stop) })
+		<-done
+	}
+}
+
+// Contains returns true if the value is in the robin.
+func (b *BlockingRobin[T]) Contains(v T) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.r.Contains(v)
+}
+
+// Len returns the number of values in the robin.
+func (b *BlockingRobin[T]) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.r.Len()
+}
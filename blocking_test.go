@@ -0,0 +1,85 @@
+package robin_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/embeage/robin"
+)
+
+func TestBlockingRobinNextWait(t *testing.T) {
+	b := robin.NewBlockingRobin(robin.NewUnbounded[int]())
+
+	type result struct {
+		v  int
+		ok bool
+	}
+	results := make(chan result)
+	go func() {
+		v, ok := b.NextWait(context.Background())
+		results <- result{v, ok}
+	}()
+
+	select {
+	case res := <-results:
+		t.Fatalf("NextWait returned %v on empty robin", res)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	b.Add(1)
+	select {
+	case res := <-results:
+		if !res.ok || res.v != 1 {
+			t.Errorf("got %v, want {1 true}", res)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("NextWait did not unblock after Add")
+	}
+}
+
+func TestBlockingRobinNextWaitCanceled(t *testing.T) {
+	b := robin.NewBlockingRobin(robin.NewUnbounded[int]())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, ok := b.NextWait(ctx); ok {
+		t.Errorf("NextWait on empty robin returned true after context was done")
+	}
+}
+
+func TestBlockingRobinTicker(t *testing.T) {
+	b := robin.NewBlockingRobin(robin.NewUnbounded[int]())
+	b.Add(1, 2, 3)
+
+	c, stop := b.Ticker(time.Millisecond)
+	var got []int
+	for len(got) < 4 {
+		select {
+		case v := <-c:
+			got = append(got, v)
+		case <-time.After(time.Second):
+			t.Fatal("ticker did not send a value")
+		}
+	}
+	if want := []int{1, 2, 3, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	stop()
+	stop()
+	if _, ok := <-c; ok {
+		t.Errorf("channel not closed after stop")
+	}
+}
+
+func TestBlockingRobinTickerNonPositive(t *testing.T) {
+	b := robin.NewBlockingRobin(robin.NewUnbounded[int]())
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Ticker with zero interval did not panic")
+		}
+	}()
+	b.Ticker(0)
+}
@@ -0,0 +1,60 @@
+package robin
+
+// countingBloom is a bloom filter with a counter per slot instead of
+// a bit, so values can be removed as well as added
+type countingBloom[T comparable] struct {
+	counts []uint32
+	hashes int
+}
+
+func newCountingBloom[T comparable](size, hashes int) *countingBloom[T] {
+	if size < 1 {
+		size = 1
+	}
+	if hashes < 1 {
+		hashes = 1
+	}
+	return &countingBloom[T]{counts: make([]uint32, size), hashes: hashes}
+}
+
+// slot returns the i:th slot of a value, using double hashing to
+// derive the slots from a single hash
+func (b *countingBloom[T]) slot(h1, h2 uint64, i int) int {
+	return int((h1 + uint64(i)*h2) % uint64(len(b.counts)))
+}
+
+func (b *countingBloom[T]) add(v T) {
+	h1 := hashValue(v)
+	h2 := mix64(h1) | 1
+	for i := 0; i < b.hashes; i++ {
+		b.counts[b.slot(h1, h2, i)]++
+	}
+}
+
+// remove must only be called for values that have been added
+func (b *countingBloom[T]) remove(v T) {
+	h1 := hashValue(v)
+	h2 := mix64(h1) | 1
+	for i := 0; i < b.hashes; i++ {
+		if s := b.slot(h1, h2, i); b.counts[s] > 0 {
+			b.counts[s]--
+		}
+	}
+}
+
+func (b *countingBloom[T]) mightContain(v T) bool {
+	h1 := hashValue(v)
+	h2 := mix64(h1) | 1
+	for i := 0; i < b.hashes; i++ {
+		if b.counts[b.slot(h1, h2, i)] == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *countingBloom[T]) reset() {
+	for i := range b.counts {
+		b.counts[i] = 0
+	}
+}
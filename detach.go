@@ -0,0 +1,110 @@
+package robin
+
+// DetachedNode is a value detached from a [Robin] by [Robin.Detach],
+// together with its per-value state, so that it can be attached to
+// another robin by [Robin.Attach].
+type DetachedNode[T comparable] struct {
+	v        T
+	ext      nodeExt
+	attached bool
+}
+
+// Value returns the detached value.
+func (d *DetachedNode[T]) Value() T {
+	return d.v
+}
+
+// Detach removes a value from the robin without replacing it from the
+// buffer and returns it with its per-value state. The second return
+// value is false if the value is not in the robin or
+// [WithImmutableMembers] is set.
+func (r *Robin[T]) Detach(v T) (*DetachedNode[T], bool) {
+	node, ok := r.nodes[v]
+	if !ok || r.immutable {
+		return nil, false
+	}
+	r.retire(node)
+	if r.metrics != nil {
+		r.metrics.OnRemove(1)
+	}
+	d := &DetachedNode[T]{v: node.v}
+	if node.ext != nil {
+		d.ext = *node.ext
+	}
+	return d, true
+}
+
+// Attach adds a detached value to the robin like [Add], keeping its
+// per-value state: its serve count, whether it is paused, the time it
+// was last returned by [Next] and its weight for [WithWFQ]. Its
+// virtual finish time restarts from the virtual time of the robin,
+// and if it has been returned by Next before, [WithIdleTimeout] counts
+// from that time rather than from the time it is attached. It returns
+// false, adding nothing, if the value is already in the robin or the
+// buffer, the robin is full, or the node has already been attached.
+func (r *Robin[T]) Attach(d *DetachedNode[T]) bool {
+	if d == nil || d.attached || !r.canInsert(d.v) {
+		return false
+	}
+	d.attached = true
+	node := &node[T]{v: d.v}
+	r.track(node)
+	if d.ext != (nodeExt{}) {
+		ext := node.extend()
+		ext.serves = d.ext.serves
+		ext.paused = d.ext.paused
+		if d.ext.served {
+			ext.lastServed = d.ext.lastServed
+			ext.served = true
+		}
+		if r.wfq && d.ext.weight > 0 {
+			ext.weight = d.ext.weight
+			ext.finish = r.virtual + 1/d.ext.weight
+		}
+	}
+	if r.metrics != nil {
+		r.metrics.OnAdd(1)
+	}
+	next := r.next
+	r.attach(node, node)
+	if next != nil && (next == r.pinned || r.stableCursor) {
+		r.next = next
+	}
+	return true
+}
+
+// Transfer moves up to k values from the front of the rotation of src,
+// starting at its current position, to dst, where they are added like
+// [Robin.Add] in the same order. The values are removed from src like
+// [Robin.Remove], but without replacing them from its buffer. Values
+// that cannot be added to dst because they are already there or
+// invalid are skipped and stay in src. It stops early when every value
+// of src has been considered or dst is full, and returns the number of
+// values transferred. Values are never pushed to the buffer of dst.
+func Transfer[T comparable](src, dst *Robin[T], k int) int {
+	if src == dst || src.immutable {
+		return 0
+	}
+	src.sweepIdle()
+	var vs []T
+	node := src.next
+	for i := 0; i < len(src.nodes) && len(vs) < k; i++ {
+		if dst.maxLen > 0 && len(dst.nodes)+len(vs) >= dst.maxLen ||
+			dst.totalCap > 0 && len(dst.nodes)+dst.BufferLen()+len(vs) >= dst.totalCap {
+			break
+		}
+		if dst.canInsert(node.v) {
+			vs = append(vs, node.v)
+		}
+		node = src.step(node)
+	}
+	for _, v := range vs {
+		src.recordRemoval(v)
+		src.retire(src.nodes[v])
+	}
+	if len(vs) > 0 && src.metrics != nil {
+		src.metrics.OnRemove(len(vs))
+	}
+	dst.Add(vs...)
+	return len(vs)
+}
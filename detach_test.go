@@ -0,0 +1,132 @@
+package robin_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/embeage/robin"
+)
+
+func TestDetachAttach(t *testing.T) {
+	src := robin.NewBounded(2,
+		robin.WithBuffer[int](robin.NewLIFOBuffer[int](1)),
+		robin.WithServeCounts[int](),
+	)
+	dst := robin.NewUnbounded(robin.WithServeCounts[int]())
+	src.Add(1, 2, 3)
+	dst.Add(4, 5)
+	next(src, 3)
+
+	if _, ok := src.Detach(4); ok {
+		t.Errorf("Detach of absent value returned true")
+	}
+	d, ok := src.Detach(1)
+	if !ok || d.Value() != 1 {
+		t.Fatalf("Detach got %v, %v, want 1, true", d.Value(), ok)
+	}
+	if src.Contains(1) || src.Len() != 1 || src.BufferLen() != 1 {
+		t.Errorf("Detach should remove value without buffer replacement")
+	}
+
+	if !dst.Attach(d) {
+		t.Fatalf("Attach returned false")
+	}
+	if dst.Attach(d) {
+		t.Errorf("Attach of already attached node returned true")
+	}
+	if got, _ := dst.ServeCount(1); got != 2 {
+		t.Errorf("ServeCount after Attach got %v, want 2", got)
+	}
+	if got, want := next(dst, 3), []int{1, 4, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestDetachAttachState(t *testing.T) {
+	now := time.Unix(100, 0)
+	clock := func() time.Time { return now }
+	src := robin.NewUnbounded(robin.WithWFQ[int](), robin.WithServeHistory[int](4), robin.WithClock[int](clock))
+	dst := robin.NewUnbounded(robin.WithWFQ[int](), robin.WithServeHistory[int](4))
+	src.Add(1, 2)
+	src.SetWeight(1, 4)
+	src.Next()
+
+	d, _ := src.Detach(1)
+	dst.Add(3)
+	dst.Attach(d)
+	if at, ok := dst.LastServed(1); !ok || !at.Equal(now) {
+		t.Errorf("LastServed after Attach got %v, %v, want %v, true", at, ok, now)
+	}
+	if got := dst.VirtualFinishTimes()[1]; got != 0.25 {
+		t.Errorf("finish time after Attach got %v, want 0.25", got)
+	}
+
+	paused := robin.NewUnbounded[int]()
+	paused.Add(1, 2)
+	paused.Pause(1)
+	d, _ = paused.Detach(1)
+	dst = robin.NewUnbounded[int]()
+	dst.Add(3)
+	dst.Attach(d)
+	for i := 0; i < 2; i++ {
+		if v, _ := dst.NextActive(); v != 3 {
+			t.Errorf("NextActive after Attach got %v, want 3", v)
+		}
+	}
+}
+
+func TestTransfer(t *testing.T) {
+	src := robin.NewBounded(4, robin.WithBuffer[int](robin.NewLIFOBuffer[int](1)))
+	dst := robin.NewBounded[int](3)
+	src.Add(1, 2, 3, 4, 5)
+	src.Next()
+	dst.Add(9)
+
+	if got, want := robin.Transfer(src, dst, 1), 1; got != want {
+		t.Errorf("Transfer got %v, want %v", got, want)
+	}
+	if got, want := robin.Transfer(src, dst, 5), 1; got != want {
+		t.Errorf("Transfer to filling robin got %v, want %v", got, want)
+	}
+	if got, want := next(dst, 3), []int{3, 2, 9}; !reflect.DeepEqual(got, want) {
+		t.Errorf("dst got %v, want %v", got, want)
+	}
+	if got, want := next(src, 3), []int{4, 1, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("src got %v, want %v", got, want)
+	}
+	if src.BufferLen() != 1 {
+		t.Errorf("Transfer should not replace values from the buffer of src")
+	}
+
+	all := robin.NewUnbounded[int]()
+	if got, want := robin.Transfer(src, all, 5), 2; got != want {
+		t.Errorf("Transfer of whole robin got %v, want %v", got, want)
+	}
+	if src.Len() != 0 || all.Len() != 2 {
+		t.Errorf("got lengths %d and %d, want 0 and 2", src.Len(), all.Len())
+	}
+}
+
+func TestTransferSkipsValuesInDst(t *testing.T) {
+	src := robin.NewUnbounded(robin.WithRemovalHistory[int](3))
+	dst := robin.NewUnbounded[int]()
+	src.Add(1, 2, 3, 4)
+	dst.Add(2)
+
+	if got, want := robin.Transfer(src, dst, 2), 2; got != want {
+		t.Errorf("Transfer got %v, want %v", got, want)
+	}
+	if got, want := src.Order(), (robin.Order[int]{2, 4}); !reflect.DeepEqual(got, want) {
+		t.Errorf("src got %v, want %v", got, want)
+	}
+	if got, want := src.RemovalHistory(), []int{3, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("RemovalHistory got %v, want %v", got, want)
+	}
+	if got, want := robin.Transfer(src, dst, 5), 1; got != want {
+		t.Errorf("Transfer got %v, want %v", got, want)
+	}
+	if !dst.ContainsAll(1, 2, 3, 4) || src.Len() != 1 {
+		t.Errorf("got dst %v and src %v", dst.Order(), src.Order())
+	}
+}
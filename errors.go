@@ -0,0 +1,44 @@
+package robin
+
+import "errors"
+
+var (
+	// ErrFull is returned when a value cannot be added because the
+	// robin is full and the value cannot be buffered either.
+	ErrFull = errors.New("robin: full")
+
+	// ErrEmpty is returned when a value is requested from an empty
+	// robin or buffer.
+	ErrEmpty = errors.New("robin: empty")
+
+	// ErrDuplicate is returned when a value is already in the robin
+	// or its buffer.
+	ErrDuplicate = errors.New("robin: duplicate value")
+
+	// ErrNotFound is returned when a value is not in the robin.
+	ErrNotFound = errors.New("robin: value not found")
+
+	// ErrNoBuffer is returned when an operation requires a buffer and
+	// the robin has none.
+	ErrNoBuffer = errors.New("robin: no buffer")
+
+	// ErrImmutable is returned when a value cannot be removed because
+	// of the [WithImmutableMembers] option.
+	ErrImmutable = errors.New("robin: immutable members")
+
+	// ErrNotPermutation is returned when an order does not contain
+	// each value in the robin exactly once.
+	ErrNotPermutation = errors.New("robin: order is not a permutation of the values")
+
+	// ErrNoParser is returned when values of a type cannot be parsed
+	// because no parser has been set with [WithParser].
+	ErrNoParser = errors.New("robin: no parser")
+
+	// ErrInvalidState is returned when a [State] cannot be restored
+	// as it is.
+	ErrInvalidState = errors.New("robin: invalid state")
+
+	// ErrZeroValue is returned when the zero value is added to a robin
+	// with the [WithRejectZero] option.
+	ErrZeroValue = errors.New("robin: zero value")
+)
@@ -0,0 +1,110 @@
+package robin_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/embeage/robin"
+)
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  func() error
+		want error
+	}{
+		{
+			name: "try add duplicate",
+			err: func() error {
+				r := robin.NewUnbounded[int]()
+				r.Add(1)
+				return r.TryAdd(1)
+			},
+			want: robin.ErrDuplicate,
+		},
+		{
+			name: "try add buffered duplicate",
+			err: func() error {
+				r := robin.NewBounded(1, robin.WithBuffer[int](robin.NewLIFOBuffer[int](1)))
+				r.Add(1, 2)
+				return r.TryAdd(2)
+			},
+			want: robin.ErrDuplicate,
+		},
+		{
+			name: "try add to full robin",
+			err: func() error {
+				r := robin.NewBounded[int](1)
+				r.Add(1)
+				return r.TryAdd(2)
+			},
+			want: robin.ErrFull,
+		},
+		{
+			name: "try add to full robin with buffer",
+			err: func() error {
+				r := robin.NewBounded(1, robin.WithBuffer[int](robin.NewLIFOBuffer[int](1)))
+				r.Add(1)
+				return r.TryAdd(2)
+			},
+			want: nil,
+		},
+		{
+			name: "try remove absent value",
+			err: func() error {
+				return robin.NewUnbounded[int]().TryRemove(1)
+			},
+			want: robin.ErrNotFound,
+		},
+		{
+			name: "try remove immutable value",
+			err: func() error {
+				r := robin.NewUnbounded(robin.WithImmutableMembers[int]())
+				r.Add(1)
+				return r.TryRemove(1)
+			},
+			want: robin.ErrImmutable,
+		},
+		{
+			name: "try next on empty robin",
+			err: func() error {
+				_, err := robin.NewUnbounded[int]().TryNext()
+				return err
+			},
+			want: robin.ErrEmpty,
+		},
+		{
+			name: "pop buffer without buffer",
+			err: func() error {
+				_, err := robin.NewBounded[int](1).PopBuffer()
+				return err
+			},
+			want: robin.ErrNoBuffer,
+		},
+		{
+			name: "pop empty buffer",
+			err: func() error {
+				_, err := robin.NewBounded(1, robin.WithBuffer[int](robin.NewLIFOBuffer[int](1))).PopBuffer()
+				return err
+			},
+			want: robin.ErrEmpty,
+		},
+		{
+			name: "reorder with missing value",
+			err: func() error {
+				r := robin.NewUnbounded[int]()
+				r.Add(1, 2)
+				return r.Reorder([]int{1, 3})
+			},
+			want: robin.ErrNotPermutation,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.err(); !errors.Is(err, tc.want) {
+				t.Errorf("Test %q failed: got %v, want %v", tc.name, err, tc.want)
+			}
+		})
+	}
+}
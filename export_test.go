@@ -0,0 +1,21 @@
+package robin
+
+import "reflect"
+
+// BreakLink corrupts the robin by linking the node at the current
+// position to itself, cutting the rest of the ring off.
+func (r *Robin[T]) BreakLink() {
+	r.next.next = r.next
+}
+
+// NodesID identifies the map that keeps track of the values, so tests
+// can tell whether it has been rebuilt.
+func (r *Robin[T]) NodesID() uintptr {
+	return reflect.ValueOf(r.nodes).Pointer()
+}
+
+// CountPeak returns the largest size of the count map of the buffer
+// since it was last rebuilt, which drops when it is compacted.
+func (b *LIFOBuffer[T]) CountPeak() int {
+	return b.peak
+}
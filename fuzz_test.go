@@ -0,0 +1,85 @@
+package robin_test
+
+import (
+	"testing"
+
+	"github.com/embeage/robin"
+)
+
+func FuzzRobin(f *testing.F) {
+	f.Add([]byte{0, 4, 8, 2, 2, 5, 2, 3, 0})
+	f.Add([]byte{0, 4, 8, 12, 2, 9, 6, 4, 2, 1, 5, 2, 16})
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		r := robin.NewUnbounded[int]()
+		model := make(map[int]struct{})
+		// a bounded robin with a buffer gets the same operations and is
+		// only checked for its structure, since its values depend on
+		// the buffer
+		b := robin.NewBounded(3, robin.WithBuffer[int](robin.NewLIFOBuffer[int](2)))
+		for _, op := range ops {
+			v := int(op>>3) % 8
+			switch op % 8 {
+			case 0, 1:
+				b.Add(v)
+			case 2, 3:
+				b.Next()
+			case 4:
+				b.Remove(v)
+			case 5:
+				b.ReverseDirection()
+			case 6:
+				b.SeekAfter(v)
+			case 7:
+				b.Reset()
+			}
+			if !b.IsConsistent() || b.Len() > 3 || b.Len() < 3 && b.BufferLen() > 0 {
+				t.Fatalf("bounded robin is not consistent")
+			}
+			for v := 0; v < 8; v++ {
+				if b.Contains(v) && b.BufferContains(v) {
+					t.Fatalf("value %d is in both robin and buffer", v)
+				}
+			}
+
+			switch op % 8 {
+			case 0, 1:
+				r.Add(v)
+				model[v] = struct{}{}
+			case 2, 3:
+				got, ok := r.Next()
+				if _, in := model[got]; ok != (len(model) > 0) || ok && !in {
+					t.Fatalf("Next got %v, %v with values %v", got, ok, model)
+				}
+			case 4:
+				r.Remove(v)
+				delete(model, v)
+			case 5:
+				r.ReverseDirection()
+			case 6:
+				r.SeekAfter(v)
+			case 7:
+				r.Reset()
+				model = make(map[int]struct{})
+			}
+
+			if r.Len() != len(model) {
+				t.Fatalf("Len got %d, want %d", r.Len(), len(model))
+			}
+			for v := 0; v < 8; v++ {
+				if _, in := model[v]; r.Contains(v) != in {
+					t.Fatalf("Contains(%d) got %v, want %v", v, r.Contains(v), in)
+				}
+			}
+			if !r.IsConsistent() {
+				t.Fatalf("robin is not consistent")
+			}
+			seen := make(map[int]struct{})
+			for _, v := range r.Snapshot().ToSlice() {
+				seen[v] = struct{}{}
+			}
+			if len(seen) != len(model) {
+				t.Fatalf("traversal visited %d distinct values, want %d", len(seen), len(model))
+			}
+		}
+	})
+}
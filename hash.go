@@ -0,0 +1,55 @@
+package robin
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math"
+)
+
+// hashValue returns the 64-bit FNV-1a hash of a value. Common basic
+// types are hashed by their binary representation and anything else
+// by its default formatting.
+func hashValue[T comparable](v T) uint64 {
+	h := fnv.New64a()
+	var b [8]byte
+	switch v := any(v).(type) {
+	case string:
+		h.Write([]byte(v))
+	case int:
+		binary.LittleEndian.PutUint64(b[:], uint64(v))
+		h.Write(b[:])
+	case int64:
+		binary.LittleEndian.PutUint64(b[:], uint64(v))
+		h.Write(b[:])
+	case int32:
+		binary.LittleEndian.PutUint64(b[:], uint64(v))
+		h.Write(b[:])
+	case uint:
+		binary.LittleEndian.PutUint64(b[:], uint64(v))
+		h.Write(b[:])
+	case uint64:
+		binary.LittleEndian.PutUint64(b[:], v)
+		h.Write(b[:])
+	case uint32:
+		binary.LittleEndian.PutUint64(b[:], uint64(v))
+		h.Write(b[:])
+	case float64:
+		binary.LittleEndian.PutUint64(b[:], math.Float64bits(v))
+		h.Write(b[:])
+	default:
+		fmt.Fprintf(h, "%#v", v)
+	}
+	return h.Sum64()
+}
+
+// mix64 is the splitmix64 finalizer, used to spread hashes before
+// they are combined
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -2,7 +2,8 @@ package robin
 
 // LIFOBuffer is a stack-like buffer with a fixed capacity.
 // If the buffer is full, pushing a new value will overwrite
-// the oldest value in the buffer.
+// the oldest value in the buffer, unless the buffer is created
+// with the [WithRejectOnFull] option.
 //
 // All operations are O(1). Like [Robin], it is backed by a
 // map to keep track of the values in the buffer. When used
@@ -14,16 +15,48 @@ type LIFOBuffer[T comparable] struct {
 	n     int
 	count map[T]int
 
-	capacity int
+	capacity     int
+	rejectOnFull bool
+
+	// peak is the largest size of the count map since it was last
+	// rebuilt, see WithAutoCompact
+	peak        int
+	autoCompact float64
+}
+
+type LIFOBufferOption[T comparable] func(*LIFOBuffer[T])
+
+// WithRejectOnFull makes pushing to a full [LIFOBuffer] a no-op
+// instead of overwriting the oldest value.
+func WithRejectOnFull[T comparable]() LIFOBufferOption[T] {
+	return func(b *LIFOBuffer[T]) {
+		b.rejectOnFull = true
+	}
+}
+
+// WithAutoCompact makes the [LIFOBuffer] rebuild the map that keeps
+// track of its values, like [LIFOBuffer.Compact], when the number of
+// distinct values drops below threshold times the largest number it
+// has held since the map was last rebuilt. A threshold between 0 and 1
+// is expected; the cost of rebuilding is amortized over the removals
+// that preceded it.
+func WithAutoCompact[T comparable](threshold float64) LIFOBufferOption[T] {
+	return func(b *LIFOBuffer[T]) {
+		b.autoCompact = threshold
+	}
 }
 
 // NewLIFOBuffer creates a new [LIFOBuffer] with the given capacity.
-func NewLIFOBuffer[T comparable](capacity int) *LIFOBuffer[T] {
-	return &LIFOBuffer[T]{
+func NewLIFOBuffer[T comparable](capacity int, options ...LIFOBufferOption[T]) *LIFOBuffer[T] {
+	b := &LIFOBuffer[T]{
 		capacity: capacity,
 		buf:      make([]T, capacity),
 		count:    make(map[T]int, capacity),
 	}
+	for _, option := range options {
+		option(b)
+	}
+	return b
 }
 
 // keeps track of the number of total values as well as the
@@ -31,6 +64,9 @@ func NewLIFOBuffer[T comparable](capacity int) *LIFOBuffer[T] {
 func (b *LIFOBuffer[T]) incCount(v T) {
 	b.n++
 	b.count[v]++
+	if len(b.count) > b.peak {
+		b.peak = len(b.count)
+	}
 }
 
 // decrements counts and removes the value from the map if
@@ -40,18 +76,55 @@ func (b *LIFOBuffer[T]) decCount(v T) {
 	b.count[v]--
 	if b.count[v] == 0 {
 		delete(b.count, v)
+		if float64(len(b.count)) < b.autoCompact*float64(b.peak) {
+			b.Compact()
+		}
 	}
 }
 
 // Push a value to the buffer. If the buffer is full, the oldest
-// value will be overwritten.
+// value will be overwritten, or the value is ignored if the buffer
+// rejects values when full.
 func (b *LIFOBuffer[T]) Push(v T) {
+	b.TryPush(v)
+}
+
+// TryPush pushes a value to the buffer and returns true if the value
+// was accepted. It only returns false if the buffer has no capacity,
+// or if it is full and was created with the [WithRejectOnFull] option.
+func (b *LIFOBuffer[T]) TryPush(v T) bool {
+	if b.capacity <= 0 {
+		return false
+	}
 	if b.n == b.capacity {
+		if b.rejectOnFull {
+			return false
+		}
 		b.decCount(b.buf[b.i])
 	}
 	b.incCount(v)
 	b.buf[b.i] = v
 	b.i = (b.i + 1) % b.capacity
+	return true
+}
+
+// PeekOverwrite returns the value the next push would overwrite, which
+// is the oldest value in a full buffer. The second return value is
+// false if the buffer is not full or rejects values when full.
+func (b *LIFOBuffer[T]) PeekOverwrite() (T, bool) {
+	if b.rejectOnFull || b.n < b.capacity || b.capacity <= 0 {
+		return *new(T), false
+	}
+	return b.buf[b.i], true
+}
+
+// values returns the values in the order they would be popped
+func (b *LIFOBuffer[T]) values() []T {
+	vs := make([]T, 0, b.n)
+	for k := 1; k <= b.n; k++ {
+		vs = append(vs, b.buf[(b.i-k+b.capacity)%b.capacity])
+	}
+	return vs
 }
 
 // Pop a value from the buffer. If the buffer is empty, the
@@ -66,6 +139,30 @@ func (b *LIFOBuffer[T]) Pop() (T, bool) {
 	return v, true
 }
 
+// Remove the most recent occurrence of a value from the buffer,
+// keeping the order of the other values. It returns false if the
+// value is not in the buffer. Unlike the other operations, Remove
+// is O(n).
+func (b *LIFOBuffer[T]) Remove(v T) bool {
+	if !b.Contains(v) {
+		return false
+	}
+	// index of the k:th most recent value
+	at := func(k int) int {
+		return (b.i - 1 - k + 2*b.capacity) % b.capacity
+	}
+	k := 0
+	for b.buf[at(k)] != v {
+		k++
+	}
+	for ; k > 0; k-- {
+		b.buf[at(k)] = b.buf[at(k-1)]
+	}
+	b.i = at(0)
+	b.decCount(v)
+	return true
+}
+
 // Contains returns true if the value is in the buffer.
 func (b *LIFOBuffer[T]) Contains(v T) bool {
 	_, ok := b.count[v]
@@ -77,9 +174,56 @@ func (b *LIFOBuffer[T]) Len() int {
 	return b.n
 }
 
+// Cap returns the capacity of the buffer.
+func (b *LIFOBuffer[T]) Cap() int {
+	return b.capacity
+}
+
+// Grow increases the capacity of the buffer to newCapacity, keeping
+// its values and their order. It never shrinks the buffer, so a
+// capacity that is not larger than the current one is ignored. It is
+// O(n).
+func (b *LIFOBuffer[T]) Grow(newCapacity int) {
+	if newCapacity <= b.capacity {
+		return
+	}
+	buf := make([]T, newCapacity)
+	for k := 0; k < b.n; k++ {
+		buf[k] = b.buf[(b.i-b.n+k+b.capacity)%b.capacity]
+	}
+	b.buf = buf
+	b.i = b.n
+	b.capacity = newCapacity
+}
+
+// Compact rebuilds the map that keeps track of the values in the
+// buffer, releasing any capacity it has retained from values that are
+// no longer in the buffer. The values are kept. It is O(n).
+func (b *LIFOBuffer[T]) Compact() {
+	count := make(map[T]int, len(b.count))
+	for v, n := range b.count {
+		count[v] = n
+	}
+	b.count = count
+	b.peak = len(count)
+}
+
+// Clone returns an independent copy of the buffer.
+func (b *LIFOBuffer[T]) Clone() *LIFOBuffer[T] {
+	c := *b
+	c.buf = make([]T, len(b.buf))
+	copy(c.buf, b.buf)
+	c.count = make(map[T]int, len(b.count))
+	for v, n := range b.count {
+		c.count[v] = n
+	}
+	return &c
+}
+
 // Reset the buffer.
 func (b *LIFOBuffer[T]) Reset() {
 	b.i = 0
 	b.n = 0
 	b.count = make(map[T]int, b.capacity)
+	b.peak = 0
 }
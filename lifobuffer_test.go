@@ -11,6 +11,7 @@ func TestLIFOBuffer(t *testing.T) {
 	tests := []struct {
 		name       string
 		capacity   int
+		options    []robin.LIFOBufferOption[int]
 		operations []func(*robin.LIFOBuffer[int]) interface{}
 		want       []interface{}
 	}{
@@ -55,6 +56,60 @@ func TestLIFOBuffer(t *testing.T) {
 			},
 			want: []interface{}{false, 3},
 		},
+		{
+			name:     "try push to full buffer should overwrite by default",
+			capacity: 2,
+			operations: []func(*robin.LIFOBuffer[int]) interface{}{
+				func(b *robin.LIFOBuffer[int]) interface{} { b.Push(1); b.Push(2); return b.TryPush(3) },
+				func(b *robin.LIFOBuffer[int]) interface{} { return b.Contains(1) },
+				func(b *robin.LIFOBuffer[int]) interface{} { return b.Len() },
+			},
+			want: []interface{}{true, false, 2},
+		},
+		{
+			name:     "pushing to full rejecting buffer should be no-op",
+			capacity: 2,
+			options:  []robin.LIFOBufferOption[int]{robin.WithRejectOnFull[int]()},
+			operations: []func(*robin.LIFOBuffer[int]) interface{}{
+				func(b *robin.LIFOBuffer[int]) interface{} { b.Push(1); b.Push(2); b.Push(3); return b.Contains(3) },
+				func(b *robin.LIFOBuffer[int]) interface{} { return b.TryPush(4) },
+				func(b *robin.LIFOBuffer[int]) interface{} { return b.Contains(1) },
+				func(b *robin.LIFOBuffer[int]) interface{} { v, _ := b.Pop(); return v },
+				func(b *robin.LIFOBuffer[int]) interface{} { return b.TryPush(5) },
+				func(b *robin.LIFOBuffer[int]) interface{} { v, _ := b.Pop(); return v },
+			},
+			want: []interface{}{false, false, true, 2, true, 5},
+		},
+		{
+			name:     "pushing to zero capacity buffer should be no-op",
+			capacity: 0,
+			operations: []func(*robin.LIFOBuffer[int]) interface{}{
+				func(b *robin.LIFOBuffer[int]) interface{} { return b.TryPush(1) },
+				func(b *robin.LIFOBuffer[int]) interface{} { b.Push(2); return b.Len() },
+				func(b *robin.LIFOBuffer[int]) interface{} { _, ok := b.Pop(); return ok },
+			},
+			want: []interface{}{false, 0, false},
+		},
+		{
+			name:     "remove should keep order of remaining values",
+			capacity: 3,
+			operations: []func(*robin.LIFOBuffer[int]) interface{}{
+				func(b *robin.LIFOBuffer[int]) interface{} {
+					b.Push(1)
+					b.Push(2)
+					b.Push(3)
+					b.Push(4)
+					return b.Remove(3)
+				},
+				func(b *robin.LIFOBuffer[int]) interface{} { return b.Remove(1) },
+				func(b *robin.LIFOBuffer[int]) interface{} { return b.Len() },
+				func(b *robin.LIFOBuffer[int]) interface{} { b.Push(5); v, _ := b.Pop(); return v },
+				func(b *robin.LIFOBuffer[int]) interface{} { v, _ := b.Pop(); return v },
+				func(b *robin.LIFOBuffer[int]) interface{} { v, _ := b.Pop(); return v },
+				func(b *robin.LIFOBuffer[int]) interface{} { return b.Contains(3) },
+			},
+			want: []interface{}{true, false, 2, 5, 4, 2, false},
+		},
 		{
 			name:     "basic reset",
 			capacity: 2,
@@ -64,11 +119,65 @@ func TestLIFOBuffer(t *testing.T) {
 			},
 			want: []interface{}{0, false},
 		},
+		{
+			name:     "peek overwrite should return oldest value of full buffer",
+			capacity: 2,
+			operations: []func(*robin.LIFOBuffer[int]) interface{}{
+				func(b *robin.LIFOBuffer[int]) interface{} { b.Push(1); _, ok := b.PeekOverwrite(); return ok },
+				func(b *robin.LIFOBuffer[int]) interface{} { b.Push(2); v, _ := b.PeekOverwrite(); return v },
+				func(b *robin.LIFOBuffer[int]) interface{} { b.Push(3); v, _ := b.PeekOverwrite(); return v },
+				func(b *robin.LIFOBuffer[int]) interface{} { b.Pop(); _, ok := b.PeekOverwrite(); return ok },
+			},
+			want: []interface{}{false, 1, 2, false},
+		},
+		{
+			name:     "peek overwrite on buffer rejecting values when full",
+			capacity: 1,
+			options:  []robin.LIFOBufferOption[int]{robin.WithRejectOnFull[int]()},
+			operations: []func(*robin.LIFOBuffer[int]) interface{}{
+				func(b *robin.LIFOBuffer[int]) interface{} { b.Push(1); _, ok := b.PeekOverwrite(); return ok },
+			},
+			want: []interface{}{false},
+		},
+		{
+			name:     "growing should keep pop order and raise capacity",
+			capacity: 3,
+			operations: []func(*robin.LIFOBuffer[int]) interface{}{
+				func(b *robin.LIFOBuffer[int]) interface{} {
+					b.Push(1)
+					b.Push(2)
+					b.Push(3)
+					b.Push(4)
+					b.Grow(5)
+					return b.Cap()
+				},
+				func(b *robin.LIFOBuffer[int]) interface{} { b.Push(5); b.Push(6); return b.Len() },
+				func(b *robin.LIFOBuffer[int]) interface{} { b.Push(7); return b.Contains(2) },
+				func(b *robin.LIFOBuffer[int]) interface{} {
+					var vs []int
+					for b.Len() > 0 {
+						v, _ := b.Pop()
+						vs = append(vs, v)
+					}
+					return vs
+				},
+			},
+			want: []interface{}{5, 5, false, []int{7, 6, 5, 4, 3}},
+		},
+		{
+			name:     "growing should not shrink",
+			capacity: 2,
+			operations: []func(*robin.LIFOBuffer[int]) interface{}{
+				func(b *robin.LIFOBuffer[int]) interface{} { b.Push(1); b.Push(2); b.Grow(1); return b.Cap() },
+				func(b *robin.LIFOBuffer[int]) interface{} { v, _ := b.Pop(); return v },
+			},
+			want: []interface{}{2, 2},
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			b := robin.NewLIFOBuffer[int](tc.capacity)
+			b := robin.NewLIFOBuffer[int](tc.capacity, tc.options...)
 			var got []interface{}
 			for _, op := range tc.operations {
 				got = append(got, op(b))
@@ -80,3 +189,83 @@ func TestLIFOBuffer(t *testing.T) {
 		})
 	}
 }
+
+func TestLIFOBufferClone(t *testing.T) {
+	b := robin.NewLIFOBuffer[int](3)
+	b.Push(1)
+	b.Push(2)
+
+	c := b.Clone()
+	c.Push(3)
+	b.Pop()
+
+	if got, want := []interface{}{b.Len(), b.Contains(2), b.Contains(3)}, []interface{}{1, false, false}; !reflect.DeepEqual(got, want) {
+		t.Errorf("original got %v, want %v", got, want)
+	}
+
+	var got []int
+	for v, ok := c.Pop(); ok; v, ok = c.Pop() {
+		got = append(got, v)
+	}
+	if want := []int{3, 2, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("clone got %v, want %v", got, want)
+	}
+	if got, want := b.Len(), 1; got != want {
+		t.Errorf("original Len after draining clone got %v, want %v", got, want)
+	}
+}
+
+func TestLIFOBufferCompact(t *testing.T) {
+	b := robin.NewLIFOBuffer[int](1000)
+	for i := 0; i < 1000; i++ {
+		b.Push(i)
+	}
+	for i := 0; i < 990; i++ {
+		b.Pop()
+	}
+
+	b.Compact()
+	if got, want := b.Len(), 10; got != want {
+		t.Errorf("Len got %v, want %v", got, want)
+	}
+	for i := 0; i < 1000; i++ {
+		if got, want := b.Contains(i), i < 10; got != want {
+			t.Errorf("Contains(%d) got %v, want %v", i, got, want)
+		}
+	}
+	if v, _ := b.Pop(); v != 9 {
+		t.Errorf("Pop got %v, want 9", v)
+	}
+}
+
+func TestLIFOBufferAutoCompact(t *testing.T) {
+	b := robin.NewLIFOBuffer(1000, robin.WithAutoCompact[int](0.25))
+	for i := 0; i < 1000; i++ {
+		b.Push(i)
+	}
+	for i := 0; i < 700; i++ {
+		b.Pop()
+	}
+	// the map has not been rebuilt while it holds enough values
+	if got, want := b.CountPeak(), 1000; got != want {
+		t.Errorf("CountPeak got %v, want %v", got, want)
+	}
+
+	for i := 0; i < 51; i++ {
+		b.Pop()
+	}
+	if got, want := b.CountPeak(), 249; got != want {
+		t.Errorf("CountPeak after compaction got %v, want %v", got, want)
+	}
+	if got, want := b.Len(), 249; got != want {
+		t.Errorf("Len got %v, want %v", got, want)
+	}
+	for i := 0; i < 1000; i++ {
+		if got, want := b.Contains(i), i < 249; got != want {
+			t.Fatalf("Contains(%d) got %v, want %v", i, got, want)
+		}
+	}
+	if v, _ := b.Pop(); v != 248 {
+		t.Errorf("Pop got %v, want 248", v)
+	}
+}
@@ -0,0 +1,145 @@
+package robin
+
+type lruEntry[T comparable] struct {
+	v     T
+	older *lruEntry[T]
+	newer *lruEntry[T]
+}
+
+// LRUBuffer is a buffer with a fixed capacity that keeps its values
+// ordered by recency. Pushing a value already in the buffer refreshes
+// it to most recent instead of adding it again. Popping returns the
+// most recent value, and pushing a new value to a full buffer evicts
+// the least recent value.
+//
+// All operations are O(1). Unlike [LIFOBuffer], re-pushing a value
+// protects it from being evicted by subsequent pushes.
+type LRUBuffer[T comparable] struct {
+	oldest  *lruEntry[T]
+	newest  *lruEntry[T]
+	entries map[T]*lruEntry[T]
+
+	capacity int
+}
+
+// NewLRUBuffer creates a new [LRUBuffer] with the given capacity.
+func NewLRUBuffer[T comparable](capacity int) *LRUBuffer[T] {
+	return &LRUBuffer[T]{
+		capacity: capacity,
+		entries:  make(map[T]*lruEntry[T], capacity),
+	}
+}
+
+// removes an entry from the recency list
+func (b *LRUBuffer[T]) unlink(e *lruEntry[T]) {
+	if e.older != nil {
+		e.older.newer = e.newer
+	} else {
+		b.oldest = e.newer
+	}
+	if e.newer != nil {
+		e.newer.older = e.older
+	} else {
+		b.newest = e.older
+	}
+	e.older = nil
+	e.newer = nil
+}
+
+// appends an entry to the recency list as the most recent
+func (b *LRUBuffer[T]) append(e *lruEntry[T]) {
+	e.older = b.newest
+	if b.newest != nil {
+		b.newest.newer = e
+	} else {
+		b.oldest = e
+	}
+	b.newest = e
+}
+
+// Push a value to the buffer as the most recent value. If the value
+// is already in the buffer, it is moved to most recent. Otherwise, if
+// the buffer is full, the least recent value is evicted.
+func (b *LRUBuffer[T]) Push(v T) {
+	if b.capacity <= 0 {
+		return
+	}
+	if e, ok := b.entries[v]; ok {
+		b.unlink(e)
+		b.append(e)
+		return
+	}
+	if len(b.entries) == b.capacity {
+		e := b.oldest
+		b.unlink(e)
+		delete(b.entries, e.v)
+	}
+	e := &lruEntry[T]{v: v}
+	b.entries[v] = e
+	b.append(e)
+}
+
+// PeekOverwrite returns the value pushing a new value would evict,
+// which is the least recent value in a full buffer. The second return
+// value is false if the buffer is not full.
+func (b *LRUBuffer[T]) PeekOverwrite() (T, bool) {
+	if b.capacity <= 0 || len(b.entries) < b.capacity {
+		return *new(T), false
+	}
+	return b.oldest.v, true
+}
+
+// values returns the values in the order they would be popped
+func (b *LRUBuffer[T]) values() []T {
+	vs := make([]T, 0, len(b.entries))
+	for e := b.newest; e != nil; e = e.older {
+		vs = append(vs, e.v)
+	}
+	return vs
+}
+
+// Pop the most recent value from the buffer. If the buffer is empty,
+// the second return value is false.
+func (b *LRUBuffer[T]) Pop() (T, bool) {
+	e := b.newest
+	if e == nil {
+		return *new(T), false
+	}
+	b.unlink(e)
+	delete(b.entries, e.v)
+	return e.v, true
+}
+
+// Remove a value from the buffer. It returns false if the value is
+// not in the buffer.
+func (b *LRUBuffer[T]) Remove(v T) bool {
+	e, ok := b.entries[v]
+	if ok {
+		b.unlink(e)
+		delete(b.entries, v)
+	}
+	return ok
+}
+
+// Contains returns true if the value is in the buffer.
+func (b *LRUBuffer[T]) Contains(v T) bool {
+	_, ok := b.entries[v]
+	return ok
+}
+
+// Len returns the number of values in the buffer.
+func (b *LRUBuffer[T]) Len() int {
+	return len(b.entries)
+}
+
+// Cap returns the capacity of the buffer.
+func (b *LRUBuffer[T]) Cap() int {
+	return b.capacity
+}
+
+// Reset the buffer.
+func (b *LRUBuffer[T]) Reset() {
+	b.oldest = nil
+	b.newest = nil
+	b.entries = make(map[T]*lruEntry[T], b.capacity)
+}
@@ -0,0 +1,95 @@
+package robin_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/embeage/robin"
+)
+
+func TestLRUBuffer(t *testing.T) {
+	tests := []struct {
+		name       string
+		capacity   int
+		operations []func(*robin.LRUBuffer[int]) interface{}
+		want       []interface{}
+	}{
+		{
+			name:     "basic push and pop",
+			capacity: 2,
+			operations: []func(*robin.LRUBuffer[int]) interface{}{
+				func(b *robin.LRUBuffer[int]) interface{} { b.Push(1); b.Push(2); v, _ := b.Pop(); return v },
+				func(b *robin.LRUBuffer[int]) interface{} { v, _ := b.Pop(); return v },
+				func(b *robin.LRUBuffer[int]) interface{} { _, ok := b.Pop(); return ok },
+			},
+			want: []interface{}{2, 1, false},
+		},
+		{
+			name:     "pushing to full buffer should evict least recent value",
+			capacity: 2,
+			operations: []func(*robin.LRUBuffer[int]) interface{}{
+				func(b *robin.LRUBuffer[int]) interface{} { b.Push(1); b.Push(2); b.Push(3); return b.Contains(1) },
+				func(b *robin.LRUBuffer[int]) interface{} { return b.Len() },
+				func(b *robin.LRUBuffer[int]) interface{} { v, _ := b.Pop(); return v },
+			},
+			want: []interface{}{false, 2, 3},
+		},
+		{
+			name:     "peek overwrite should return least recent value when full",
+			capacity: 2,
+			operations: []func(*robin.LRUBuffer[int]) interface{}{
+				func(b *robin.LRUBuffer[int]) interface{} { b.Push(1); _, ok := b.PeekOverwrite(); return ok },
+				func(b *robin.LRUBuffer[int]) interface{} { b.Push(2); v, _ := b.PeekOverwrite(); return v },
+				func(b *robin.LRUBuffer[int]) interface{} { b.Push(1); v, _ := b.PeekOverwrite(); return v },
+			},
+			want: []interface{}{false, 1, 2},
+		},
+		{
+			name:     "re-pushing value should protect it from eviction",
+			capacity: 2,
+			operations: []func(*robin.LRUBuffer[int]) interface{}{
+				func(b *robin.LRUBuffer[int]) interface{} { b.Push(1); b.Push(2); b.Push(1); return b.Len() },
+				func(b *robin.LRUBuffer[int]) interface{} { b.Push(3); return b.Contains(1) },
+				func(b *robin.LRUBuffer[int]) interface{} { return b.Contains(2) },
+				func(b *robin.LRUBuffer[int]) interface{} { v, _ := b.Pop(); return v },
+				func(b *robin.LRUBuffer[int]) interface{} { v, _ := b.Pop(); return v },
+			},
+			want: []interface{}{2, true, false, 3, 1},
+		},
+		{
+			name:     "basic remove",
+			capacity: 2,
+			operations: []func(*robin.LRUBuffer[int]) interface{}{
+				func(b *robin.LRUBuffer[int]) interface{} { b.Push(1); b.Push(2); return b.Remove(2) },
+				func(b *robin.LRUBuffer[int]) interface{} { return b.Remove(2) },
+				func(b *robin.LRUBuffer[int]) interface{} { return b.Len() },
+				func(b *robin.LRUBuffer[int]) interface{} { v, _ := b.Pop(); return v },
+			},
+			want: []interface{}{true, false, 1, 1},
+		},
+		{
+			name:     "basic reset",
+			capacity: 2,
+			operations: []func(*robin.LRUBuffer[int]) interface{}{
+				func(b *robin.LRUBuffer[int]) interface{} { b.Push(1); b.Reset(); return b.Len() },
+				func(b *robin.LRUBuffer[int]) interface{} { return b.Contains(1) },
+				func(b *robin.LRUBuffer[int]) interface{} { _, ok := b.Pop(); return ok },
+			},
+			want: []interface{}{0, false, false},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			b := robin.NewLRUBuffer[int](tc.capacity)
+			var got []interface{}
+			for _, op := range tc.operations {
+				got = append(got, op(b))
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Test %q failed: got %v, want %v", tc.name, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package robin
+
+// Metrics receives notifications of the operations on a [Robin], see
+// [WithMetrics]. Values are passed as any so that a single
+// implementation can be shared by robins of different types.
+type Metrics interface {
+	// OnAdd is called with the number of values added to the robin by
+	// an operation, including values replacing removed ones from the
+	// buffer.
+	OnAdd(n int)
+	// OnRemove is called with the number of values removed from the
+	// robin by an operation, whether removed explicitly, after their
+	// serve limit or after being idle. Evicted values are reported by
+	// OnEvict instead.
+	OnRemove(n int)
+	// OnNext is called with each value returned by Next.
+	OnNext(v any)
+	// OnEvict is called with each value lost to make room for another,
+	// either evicted from the robin by [WithEvictOldest] or
+	// overwritten in the buffer.
+	OnEvict(v any)
+	// OnBufferPush is called for each value pushed to the buffer.
+	OnBufferPush()
+	// OnBufferPop is called for each value popped from the buffer.
+	OnBufferPop()
+}
+
+// NopMetrics is a [Metrics] that ignores all notifications. Robins
+// without [WithMetrics] skip the notifications altogether.
+type NopMetrics struct{}
+
+func (NopMetrics) OnAdd(n int)    {}
+func (NopMetrics) OnRemove(n int) {}
+func (NopMetrics) OnNext(v any)   {}
+func (NopMetrics) OnEvict(v any)  {}
+func (NopMetrics) OnBufferPush()  {}
+func (NopMetrics) OnBufferPop()   {}
+
+// WithMetrics sets the [Metrics] notified of the operations on the
+// robin. A nil m disables the notifications.
+func WithMetrics[T comparable](m Metrics) BoundedOption[T] {
+	return func(r *Robin[T]) {
+		r.metrics = m
+	}
+}
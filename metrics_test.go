@@ -0,0 +1,61 @@
+package robin_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/embeage/robin"
+)
+
+// recordingMetrics records the notifications it receives
+type recordingMetrics struct {
+	events []string
+}
+
+func (m *recordingMetrics) record(format string, args ...any) {
+	m.events = append(m.events, fmt.Sprintf(format, args...))
+}
+
+func (m *recordingMetrics) OnAdd(n int)    { m.record("add %d", n) }
+func (m *recordingMetrics) OnRemove(n int) { m.record("remove %d", n) }
+func (m *recordingMetrics) OnNext(v any)   { m.record("next %v", v) }
+func (m *recordingMetrics) OnEvict(v any)  { m.record("evict %v", v) }
+func (m *recordingMetrics) OnBufferPush()  { m.record("push") }
+func (m *recordingMetrics) OnBufferPop()   { m.record("pop") }
+
+func TestMetrics(t *testing.T) {
+	m := &recordingMetrics{}
+	r := robin.NewBounded(2,
+		robin.WithBuffer[int](robin.NewLIFOBuffer[int](1)),
+		robin.WithMetrics[int](m),
+	)
+
+	r.Add(1, 2, 3, 4)
+	r.Next()
+	r.Remove(1, 9)
+	r.PopBuffer()
+	r.Remove(9)
+
+	want := []string{"push", "push", "evict 3", "add 2", "next 1", "pop", "add 1", "remove 1"}
+	if !reflect.DeepEqual(m.events, want) {
+		t.Errorf("got %v, want %v", m.events, want)
+	}
+}
+
+func TestNopMetrics(t *testing.T) {
+	r := robin.NewUnbounded(robin.WithMetrics[int](robin.NopMetrics{}))
+	r.Add(1, 2)
+	r.Remove(1)
+	if v, _ := r.Next(); v != 2 {
+		t.Errorf("Next got %v, want 2", v)
+	}
+}
+
+func TestNoMetricsNextAllocs(t *testing.T) {
+	r := robin.NewUnbounded[int]()
+	r.Add(1000, 2000, 3000)
+	if n := testing.AllocsPerRun(100, func() { r.Next() }); n != 0 {
+		t.Errorf("Next without metrics allocated %v times, want 0", n)
+	}
+}
@@ -0,0 +1,34 @@
+package robin
+
+import "fmt"
+
+// Order is a list of values in rotation order, see [Robin.Order].
+type Order[T comparable] []T
+
+// Order returns the values of the robin in rotation order, starting
+// with the value a subsequent call to [Robin.Next] would return. It is
+// O(n).
+func (r *Robin[T]) Order() Order[T] {
+	return Order[T](r.values())
+}
+
+// String returns the values formatted like a slice, e.g. [1 2 3].
+func (o Order[T]) String() string {
+	return fmt.Sprint([]T(o))
+}
+
+// Contains returns true if the value is in the order.
+func (o Order[T]) Contains(v T) bool {
+	return o.Index(v) >= 0
+}
+
+// Index returns the position of the value in the order, or -1 if it
+// is not in the order.
+func (o Order[T]) Index(v T) int {
+	for i, u := range o {
+		if u == v {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,34 @@
+package robin_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/embeage/robin"
+)
+
+func TestOrder(t *testing.T) {
+	r := robin.NewUnbounded[int]()
+	if got, want := r.Order().String(), "[]"; got != want {
+		t.Errorf("String on empty robin got %q, want %q", got, want)
+	}
+
+	r.Add(1, 2, 3)
+	r.Next()
+	o := r.Order()
+	if got, want := o.String(), "[2 3 1]"; got != want {
+		t.Errorf("String got %q, want %q", got, want)
+	}
+	if got, want := fmt.Sprintf("%v", o), "[2 3 1]"; got != want {
+		t.Errorf("Sprintf got %q, want %q", got, want)
+	}
+	if got, want := o.Index(1), 2; got != want {
+		t.Errorf("Index got %v, want %v", got, want)
+	}
+	if o.Contains(4) || !o.Contains(3) {
+		t.Errorf("Contains got %v and %v, want false and true", o.Contains(4), o.Contains(3))
+	}
+	if v, _ := r.Next(); v != 2 {
+		t.Errorf("Order should not advance robin, Next got %v, want 2", v)
+	}
+}
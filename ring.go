@@ -0,0 +1,36 @@
+package robin
+
+import "container/ring"
+
+// ToRing returns a [ring.Ring] holding the values of the robin in
+// rotation order, with the value a subsequent call to [Robin.Next]
+// would return as the current element. If the robin is empty, nil is
+// returned.
+func (r *Robin[T]) ToRing() *ring.Ring {
+	vs := r.values()
+	if len(vs) == 0 {
+		return nil
+	}
+	rg := ring.New(len(vs))
+	for _, v := range vs {
+		rg.Value = v
+		rg = rg.Next()
+	}
+	return rg
+}
+
+// FromRing creates a new unbounded [Robin] with the values of the ring
+// added in ring order, starting from the current element, so that it
+// is the first value returned by [Robin.Next]. Duplicates and values
+// that are not of type T are ignored.
+func FromRing[T comparable](rg *ring.Ring) *Robin[T] {
+	var vs []T
+	rg.Do(func(v any) {
+		if v, ok := v.(T); ok {
+			vs = append(vs, v)
+		}
+	})
+	r := NewUnbounded[T]()
+	r.Add(vs...)
+	return r
+}
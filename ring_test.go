@@ -0,0 +1,46 @@
+package robin_test
+
+import (
+	"container/ring"
+	"reflect"
+	"testing"
+
+	"github.com/embeage/robin"
+)
+
+func TestRingRoundTrip(t *testing.T) {
+	r := robin.NewUnbounded[int]()
+	r.Add(1, 2, 3, 4)
+	r.Next()
+
+	rg := r.ToRing()
+	var got []interface{}
+	rg.Do(func(v any) { got = append(got, v) })
+	if want := []interface{}{2, 3, 4, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ToRing got %v, want %v", got, want)
+	}
+
+	back := robin.FromRing[int](rg)
+	if got, want := next(back, 5), []int{2, 3, 4, 1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FromRing got %v, want %v", got, want)
+	}
+}
+
+func TestFromRingDeduplicates(t *testing.T) {
+	rg := ring.New(4)
+	for _, v := range []any{1, "a", 2, 1} {
+		rg.Value = v
+		rg = rg.Next()
+	}
+
+	r := robin.FromRing[int](rg)
+	if got, want := next(r, 3), []int{1, 2, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if robin.NewUnbounded[int]().ToRing() != nil {
+		t.Errorf("ToRing on empty robin should return nil")
+	}
+	if got := robin.FromRing[int](nil).Len(); got != 0 {
+		t.Errorf("FromRing on nil ring got Len %v, want 0", got)
+	}
+}
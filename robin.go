@@ -1,5 +1,14 @@
 package robin
 
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
 type Buffer[T comparable] interface {
 	Push(v T)
 	Pop() (T, bool)
@@ -8,10 +17,77 @@ type Buffer[T comparable] interface {
 	Reset()
 }
 
+// removableBuffer is implemented by buffers that support removing
+// arbitrary values
+type removableBuffer[T comparable] interface {
+	Remove(v T) bool
+}
+
+// overwritingBuffer is implemented by buffers that can report the
+// value a push would overwrite
+type overwritingBuffer[T comparable] interface {
+	PeekOverwrite() (T, bool)
+}
+
+// cappedBuffer is implemented by buffers with a fixed capacity
+type cappedBuffer[T comparable] interface {
+	Cap() int
+}
+
+// listingBuffer is implemented by buffers that can list their values
+// in the order they would be popped
+type listingBuffer[T comparable] interface {
+	values() []T
+}
+
 type node[T comparable] struct {
 	v    T
 	prev *node[T]
 	next *node[T]
+
+	// neighbours in insertion order
+	older *node[T]
+	newer *node[T]
+
+	ext *nodeExt
+}
+
+// nodeExt holds the per-value state only needed by some options, so
+// that it is only allocated for the nodes of robins using them
+type nodeExt struct {
+	serves int
+	paused bool
+
+	// lastServed is the time the value was last returned by Next, or
+	// the time it was added if served is false
+	lastServed time.Time
+	served     bool
+
+	// weight and virtual finish time, see WithWFQ; track extends
+	// every node of a robin with WFQ, so they can be read directly
+	weight float64
+	finish float64
+}
+
+// extend returns the extension of the node, allocating it if needed
+func (n *node[T]) extend() *nodeExt {
+	if n.ext == nil {
+		n.ext = &nodeExt{}
+	}
+	return n.ext
+}
+
+// serves returns the serve count of the node
+func (n *node[T]) serves() int {
+	if n.ext == nil {
+		return 0
+	}
+	return n.ext.serves
+}
+
+// paused returns true if the node is paused
+func (n *node[T]) paused() bool {
+	return n.ext != nil && n.ext.paused
 }
 
 // Robin is a round-robin data structure for comparable types that
@@ -38,15 +114,85 @@ type Robin[T comparable] struct {
 	next  *node[T]
 	nodes map[T]*node[T]
 
-	maxLen int
-	buffer Buffer[T]
+	oldest *node[T]
+	newest *node[T]
+	pinned *node[T]
+	mark   *node[T]
+
+	maxLen   int
+	buffer   Buffer[T]
+	reversed bool
+
+	totalCap       int
+	maxServes      int
+	totalServes    int
+	countServes    bool
+	evictOldest    bool
+	immutable      bool
+	preferBuffered bool
+	reAddBumps     bool
+	stableCursor   bool
+	rejectZero     bool
+
+	nextHook        func(T)
+	replacementHook func(removed, replacement T)
+	overflowHook    func(T)
+	duplicateHook   func(T)
+
+	minGap  int
+	recent  []T
+	recentI int
+	recentN int
+
+	history  []T
+	historyI int
+	historyN int
+
+	serveHistory  []ServeEvent[T]
+	serveHistoryI int
+	serveHistoryN int
+
+	bloom *countingBloom[T]
+
+	cycleStart *node[T]
+	cycles     uint64
+
+	parse     func(string) (T, error)
+	validator func(T) error
+
+	clock       func() time.Time
+	idleTimeout time.Duration
+
+	metrics Metrics
+
+	less  func(a, b T) bool
+	index []T
+
+	wfq     bool
+	virtual float64
 }
 
-// Create a new unbounded [Robin].
-func NewUnbounded[T comparable]() *Robin[T] {
-	return &Robin[T]{nodes: make(map[T]*node[T])}
+// Direction is the direction in which a [Robin] rotates.
+type Direction int
+
+const (
+	Forward Direction = iota
+	Backward
+)
+
+// Create a new unbounded [Robin]. Options that only apply to bounded
+// robins, such as [WithBuffer], are ignored.
+func NewUnbounded[T comparable](options ...BoundedOption[T]) *Robin[T] {
+	r := &Robin[T]{nodes: make(map[T]*node[T])}
+	for _, option := range options {
+		option(r)
+	}
+	r.buffer = nil
+	return r
 }
 
+// BoundedOption configures a [Robin]. Apart from [WithBuffer], the
+// options apply to unbounded robins as well.
 type BoundedOption[T comparable] func(*Robin[T])
 
 // WithBuffer sets the buffer for a bounded [Robin]. When the [Robin]
@@ -59,13 +205,296 @@ func WithBuffer[T comparable](buffer Buffer[T]) BoundedOption[T] {
 	}
 }
 
+// overflowBuffer adapts a robin to the [Buffer] interface, see
+// [WithOverflowRobin]
+type overflowBuffer[T comparable] struct {
+	r *Robin[T]
+}
+
+func (b overflowBuffer[T]) Push(v T) {
+	b.r.Add(v)
+}
+
+func (b overflowBuffer[T]) Pop() (T, bool) {
+	if b.r.next == nil {
+		return *new(T), false
+	}
+	v := b.r.next.v
+	b.r.remove(b.r.next)
+	if b.r.metrics != nil {
+		b.r.metrics.OnRemove(1)
+	}
+	return v, true
+}
+
+func (b overflowBuffer[T]) Contains(v T) bool {
+	return b.r.Contains(v) || b.r.BufferContains(v)
+}
+
+func (b overflowBuffer[T]) Len() int {
+	return b.r.Len()
+}
+
+func (b overflowBuffer[T]) Reset() {
+	b.r.Reset()
+}
+
+// WithOverflowRobin sets a second robin to be used as the buffer for
+// a bounded [Robin]. When the [Robin] is full, added values are added
+// to the overflow robin, and when a value is removed, it is replaced
+// by the value at the current position of the overflow robin, which
+// is removed from it. The overflow robin keeps its own semantics, so
+// it may be bounded and have a buffer of its own.
+func WithOverflowRobin[T comparable](overflow *Robin[T]) BoundedOption[T] {
+	return WithBuffer[T](overflowBuffer[T]{overflow})
+}
+
+// WithTotalCap caps the combined length of the robin and its buffer.
+// Once the cap is reached, added values are ignored instead of being
+// added to the robin or pushed to the buffer. If total is negative or
+// zero, there is no cap.
+func WithTotalCap[T comparable](total int) BoundedOption[T] {
+	return func(r *Robin[T]) {
+		r.totalCap = total
+	}
+}
+
+// WithMaxServes limits the number of times each value can be
+// returned by [Robin.Next]. When a value has been returned n times,
+// it is removed from the robin as if by [Robin.Remove], so it may be
+// replaced by a value from the buffer. A replacement value starts
+// with a fresh count. If n is negative or zero, there is no limit.
+func WithMaxServes[T comparable](n int) BoundedOption[T] {
+	return func(r *Robin[T]) {
+		r.maxServes = n
+	}
+}
+
+// WithTotalServes retires values after they have been returned by
+// [Robin.Next] limit times in total. Unlike [WithMaxServes], retired
+// values are not replaced from the buffer. If both are set and a value
+// reaches both limits at once, it is retired. If limit is negative or
+// zero, there is no limit.
+func WithTotalServes[T comparable](limit int) BoundedOption[T] {
+	return func(r *Robin[T]) {
+		r.totalServes = limit
+	}
+}
+
+// WithServeCounts enables counting how many times each value has
+// been returned by [Robin.Next], see [Robin.ServeCount] and
+// [Robin.ServeCounts]. A count is dropped when its value is removed.
+func WithServeCounts[T comparable]() BoundedOption[T] {
+	return func(r *Robin[T]) {
+		r.countServes = true
+	}
+}
+
+// WithNextHook sets a function that is called with every value
+// returned by [Robin.Next], after the robin has advanced and before
+// the value is returned. It is not called when the robin is empty.
+// Since the robin has already advanced, the hook may remove any value,
+// including the one returned, without affecting the rotation of the
+// remaining values.
+func WithNextHook[T comparable](hook func(T)) BoundedOption[T] {
+	return func(r *Robin[T]) {
+		r.nextHook = hook
+	}
+}
+
+// WithBufferOverflowHook sets a function to be called with a buffered
+// value that is overwritten when a value is pushed to a full buffer.
+// It only fires for buffers that overwrite values, such as
+// [LRUBuffer] and [LIFOBuffer] without [WithRejectOnFull].
+func WithBufferOverflowHook[T comparable](hook func(T)) BoundedOption[T] {
+	return func(r *Robin[T]) {
+		r.overflowHook = hook
+	}
+}
+
+// WithDuplicateHook sets a function to be called with each value that
+// is ignored by an add because it is already in the robin or in its
+// buffer. Values re-added with [WithReAddBumps] are not ignored and do
+// not fire the hook.
+func WithDuplicateHook[T comparable](hook func(T)) BoundedOption[T] {
+	return func(r *Robin[T]) {
+		r.duplicateHook = hook
+	}
+}
+
+// WithMinGap makes [Robin.Next] skip values that were returned within
+// the last k calls, so a value is not returned twice within a window
+// of k calls. Skipped values keep their position and are returned in
+// a later cycle. If every value was returned within the window, which
+// is always the case when the robin has k values or fewer, the gap
+// cannot be honored and the next value is returned as usual. This
+// makes Next O(n*k) in the worst case.
+func WithMinGap[T comparable](k int) BoundedOption[T] {
+	return func(r *Robin[T]) {
+		if k > 0 {
+			r.minGap = k
+			r.recent = make([]T, k)
+		}
+	}
+}
+
+// WithReplacementHook sets a function that is called whenever a value
+// removed from the robin is replaced by a value popped from the buffer,
+// with the removed value and the value that took its place.
+func WithReplacementHook[T comparable](hook func(removed, replacement T)) BoundedOption[T] {
+	return func(r *Robin[T]) {
+		r.replacementHook = hook
+	}
+}
+
+// WithRemovalHistory keeps the last size values removed by
+// [Robin.Remove] and its variants, see [Robin.RemovalHistory] and
+// [Robin.Undo]. Values removed for other reasons, such as eviction,
+// are not kept.
+func WithRemovalHistory[T comparable](size int) BoundedOption[T] {
+	return func(r *Robin[T]) {
+		if size > 0 {
+			r.history = make([]T, size)
+		}
+	}
+}
+
+// WithBloomIndex maintains a counting bloom filter with size counters
+// and the given number of hashes per value alongside the robin, see
+// [Robin.MightContain].
+func WithBloomIndex[T comparable](size int, hashes int) BoundedOption[T] {
+	return func(r *Robin[T]) {
+		r.bloom = newCountingBloom[T](size, hashes)
+	}
+}
+
+// WithReAddBumps makes [Robin.Add] of a value already in the robin
+// move it to the current position, so a subsequent call to [Next]
+// returns it, instead of ignoring it. Re-added values are returned
+// before values added by the same call, even if the robin is full and
+// the new values are ignored. With [WithStableCursor], re-added values
+// are still moved to the current position, while a pinned value, see
+// [Robin.Pin], keeps leading the rotation.
+func WithReAddBumps[T comparable]() BoundedOption[T] {
+	return func(r *Robin[T]) {
+		r.reAddBumps = true
+	}
+}
+
+// WithValidator sets a function to validate values added to the
+// robin. Values for which it returns an error are skipped by [Add] and
+// its variants, see [Robin.AddValidated] to get the error instead.
+func WithValidator[T comparable](fn func(T) error) BoundedOption[T] {
+	return func(r *Robin[T]) {
+		r.validator = fn
+	}
+}
+
+// WithRejectZero makes [Add] and its variants skip the zero value of
+// T, such as 0 or "", as if it failed validation with [ErrZeroValue].
+func WithRejectZero[T comparable]() BoundedOption[T] {
+	return func(r *Robin[T]) {
+		r.rejectZero = true
+	}
+}
+
+// WithClock sets the function used to get the current time, instead
+// of [time.Now]. It is mainly useful for testing time-based options
+// such as [WithIdleTimeout].
+func WithClock[T comparable](now func() time.Time) BoundedOption[T] {
+	return func(r *Robin[T]) {
+		r.clock = now
+	}
+}
+
+// WithIdleTimeout removes values that have not been returned by
+// [Robin.Next] for longer than d since they were last returned or
+// added. Idle values are removed, and replaced from the buffer if
+// possible, at the start of the next call to Add, Remove or Next,
+// which makes those O(n).
+func WithIdleTimeout[T comparable](d time.Duration) BoundedOption[T] {
+	return func(r *Robin[T]) {
+		r.idleTimeout = d
+	}
+}
+
+// WithStableCursor makes [Robin.Add] and its variants keep the current
+// position, so a subsequent call to [Next] returns the same value as
+// before the values were added. Added values are returned after all
+// other values instead.
+func WithStableCursor[T comparable]() BoundedOption[T] {
+	return func(r *Robin[T]) {
+		r.stableCursor = true
+	}
+}
+
+// WithOrderedIndex maintains a sorted index of the values alongside
+// the robin, see [Robin.Range]. It makes adding and removing values
+// O(n).
+func WithOrderedIndex[T Ordered]() BoundedOption[T] {
+	return func(r *Robin[T]) {
+		r.less = func(a, b T) bool { return a < b }
+	}
+}
+
+// ServeEvent is a value returned by [Robin.Next] and the time it was
+// returned, see [WithServeHistory].
+type ServeEvent[T comparable] struct {
+	Value T
+	Time  time.Time
+}
+
+// WithServeHistory keeps the last size values returned by
+// [Robin.Next] with the time they were returned, see
+// [Robin.ServeHistory] and [Robin.LastServed]. The time is taken from
+// the clock set by [WithClock].
+func WithServeHistory[T comparable](size int) BoundedOption[T] {
+	return func(r *Robin[T]) {
+		if size > 0 {
+			r.serveHistory = make([]ServeEvent[T], size)
+		}
+	}
+}
+
+// WithEvictOldest makes a bounded [Robin] without a buffer behave
+// like a fixed-size FIFO. When the robin is full, adding a value
+// evicts the oldest inserted value to make room for it instead of
+// ignoring the added value. A value replaced from the buffer counts
+// as newly inserted.
+func WithEvictOldest[T comparable]() BoundedOption[T] {
+	return func(r *Robin[T]) {
+		r.evictOldest = true
+	}
+}
+
+// WithImmutableMembers makes [Robin.Remove] and its variants no-ops,
+// so values are never removed on request. Add is not affected. Values
+// are still removed by options that do so explicitly, such as
+// [WithMaxServes] and [WithEvictOldest], and by [Robin.Reset].
+func WithImmutableMembers[T comparable]() BoundedOption[T] {
+	return func(r *Robin[T]) {
+		r.immutable = true
+	}
+}
+
+// WithPreferBuffered makes adding a value that is in the buffer move
+// it from the buffer to the robin if there is room, so a value is
+// never in both. The buffer must implement a Remove(v T) bool method,
+// like [LIFOBuffer] and [LRUBuffer] do, otherwise the option has no
+// effect.
+func WithPreferBuffered[T comparable]() BoundedOption[T] {
+	return func(r *Robin[T]) {
+		r.preferBuffered = true
+	}
+}
+
 // Create a new bounded [Robin] with a maximum length. An optional
 // buffer can be provided with the [WithBuffer] option. If the length
 // is negative or zero, an unbounded [Robin] will be returned and
-// any option will be ignored.
+// the [WithBuffer] option will be ignored.
 func NewBounded[T comparable](len int, options ...BoundedOption[T]) *Robin[T] {
 	if len <= 0 {
-		return NewUnbounded[T]()
+		return NewUnbounded[T](options...)
 	}
 	r := &Robin[T]{nodes: make(map[T]*node[T], len), maxLen: len}
 	for _, option := range options {
@@ -74,59 +503,271 @@ func NewBounded[T comparable](len int, options ...BoundedOption[T]) *Robin[T] {
 	return r
 }
 
+// FromSet creates a new unbounded [Robin] with the values of the set
+// added in sorted order, so the rotation order does not depend on the
+// iteration order of the map.
+func FromSet[T Ordered](set map[T]struct{}, options ...BoundedOption[T]) *Robin[T] {
+	vs := make([]T, 0, len(set))
+	for v := range set {
+		vs = append(vs, v)
+	}
+	sort.Slice(vs, func(i, j int) bool { return vs[i] < vs[j] })
+	r := NewUnbounded(options...)
+	r.Add(vs...)
+	return r
+}
+
+// FromSliceWithBuffer creates a new bounded [Robin] with the given
+// buffer, filled with the first maxLen unique values of the slice. The
+// remaining values are pushed to the buffer in order, so a buffer that
+// overflows keeps whichever values its own policy keeps.
+func FromSliceWithBuffer[T comparable](vs []T, maxLen int, buffer Buffer[T]) *Robin[T] {
+	r := NewBounded(maxLen, WithBuffer(buffer))
+	r.Add(vs...)
+	return r
+}
+
 // attach added nodes to the circular doubly linked list between the
-// next node and its predecessor and update next node to the new head
+// next node and its predecessor in the current direction and update
+// next node to the new head
 func (r *Robin[T]) attach(head, tail *node[T]) {
 	if head == nil {
 		return
 	}
 
+	// when reversed, the chain is mirrored so that it is traversed
+	// from head to tail backwards
+	first, last := head, tail
+	if r.reversed {
+		for n := head; n != nil; n = n.prev {
+			n.prev, n.next = n.next, n.prev
+		}
+		first, last = tail, head
+	}
+
 	if r.next == nil {
-		head.prev = tail
-		tail.next = head
+		first.prev = last
+		last.next = first
 		r.next = head
 		return
 	}
 
-	prev := r.next.prev
-	next := r.next
-	head.prev = prev
-	tail.next = next
-	prev.next = head
-	next.prev = tail
+	prev, next := r.next.prev, r.next
+	if r.reversed {
+		prev, next = r.next, r.next.next
+	}
+	first.prev = prev
+	last.next = next
+	prev.next = first
+	next.prev = last
 	r.next = head
 }
 
+// step returns the node following n in the current direction
+func (r *Robin[T]) step(n *node[T]) *node[T] {
+	if r.reversed {
+		return n.prev
+	}
+	return n.next
+}
+
+// indexes a node by its value and appends it to the insertion order
+func (r *Robin[T]) track(node *node[T]) {
+	r.nodes[node.v] = node
+	r.cycleStart = nil
+	if r.wfq {
+		ext := node.extend()
+		ext.weight = 1
+		ext.finish = r.virtual + 1
+	}
+	if r.less != nil {
+		i := sort.Search(len(r.index), func(i int) bool { return !r.less(r.index[i], node.v) })
+		r.index = append(r.index, node.v)
+		copy(r.index[i+1:], r.index[i:])
+		r.index[i] = node.v
+	}
+	if r.idleTimeout > 0 {
+		node.extend().lastServed = r.now()
+	}
+	if r.bloom != nil {
+		r.bloom.add(node.v)
+	}
+	node.older = r.newest
+	node.newer = nil
+	if r.newest != nil {
+		r.newest.newer = node
+	} else {
+		r.oldest = node
+	}
+	r.newest = node
+}
+
+// removes a node from the index and the insertion order
+func (r *Robin[T]) untrack(node *node[T]) {
+	delete(r.nodes, node.v)
+	r.cycleStart = nil
+	if r.less != nil {
+		i := sort.Search(len(r.index), func(i int) bool { return !r.less(r.index[i], node.v) })
+		r.index = append(r.index[:i], r.index[i+1:]...)
+	}
+	if r.bloom != nil {
+		r.bloom.remove(node.v)
+	}
+	if node == r.pinned {
+		r.pinned = nil
+	}
+	// the node may be reused for a value from the buffer, which must
+	// not inherit the mark
+	if node == r.mark {
+		r.mark = nil
+	}
+	if node.older != nil {
+		node.older.newer = node.newer
+	} else {
+		r.oldest = node.newer
+	}
+	if node.newer != nil {
+		node.newer.older = node.older
+	} else {
+		r.newest = node.older
+	}
+	node.older = nil
+	node.newer = nil
+}
+
+// atTotalCap returns true if the combined length of the robin and the
+// buffer has reached the cap set by [WithTotalCap]
+func (r *Robin[T]) atTotalCap() bool {
+	return r.totalCap > 0 && len(r.nodes)+r.BufferLen() >= r.totalCap
+}
+
 // Add values to the robin between current position. A
 // subsequent call to [Next] will return the first added value.
 // If the robin is bounded and full and a buffer is provided, the
-// values are pushed to the buffer, otherwise they are ignored.
-// Values already in the robin or in the buffer are ignored.
+// values are pushed to the buffer. Otherwise, the oldest values are
+// evicted if [WithEvictOldest] is set, or the values are ignored.
+// Values already in the robin or in the buffer are ignored, unless
+// [WithPreferBuffered] is set. Values that would exceed the cap set
+// by [WithTotalCap] are ignored as well.
 func (r *Robin[T]) Add(vs ...T) {
-	if r.maxLen > 0 && len(r.nodes) == r.maxLen && r.buffer == nil {
-		return
+	r.add(vs, false)
+}
+
+// With adds values to the robin like [Add] and returns the robin, for
+// chaining.
+func (r *Robin[T]) With(vs ...T) *Robin[T] {
+	r.Add(vs...)
+	return r
+}
+
+// AddSlice adds values to the robin like [Add] and returns the values
+// that were not added because the robin, and the buffer if any, had
+// no room for them, in the order they were given and each only once.
+// Values already in the robin or the buffer are not returned.
+func (r *Robin[T]) AddSlice(vs []T) (leftover []T) {
+	return r.add(vs, true)
+}
+
+// add values to the robin, collecting the values there was no room
+// for if collect is set
+func (r *Robin[T]) add(vs []T, collect bool) (leftover []T) {
+	r.sweepIdle()
+	if !collect && r.maxLen > 0 && len(r.nodes) == r.maxLen && r.buffer == nil && !r.evictOldest && !r.reAddBumps && r.duplicateHook == nil {
+		return nil
 	}
 
 	var (
-		head *node[T]
-		tail *node[T]
+		head   *node[T]
+		tail   *node[T]
+		bumped []*node[T]
+		added  int
+		seen   map[T]struct{}
+		// set once there is no room left, from then on only values
+		// already in the robin or the buffer are handled
+		stopped bool
 	)
+	if collect {
+		seen = make(map[T]struct{})
+	}
 
-	for _, v := range vs {
-		if _, ok := r.nodes[v]; ok {
+	for i, v := range vs {
+		if r.validate(v) != nil {
+			continue
+		}
+		if node, ok := r.nodes[v]; ok {
+			if r.reAddBumps {
+				bumped = append(bumped, node)
+			} else if r.duplicateHook != nil {
+				r.duplicateHook(v)
+			}
+			continue
+		}
+		if stopped {
+			if r.duplicateHook != nil && r.BufferContains(v) {
+				r.duplicateHook(v)
+			}
 			continue
 		}
 		if r.maxLen > 0 && len(r.nodes) == r.maxLen {
-			if r.buffer == nil {
-				break
+			if r.buffer != nil {
+				if r.buffer.Contains(v) {
+					if r.duplicateHook != nil {
+						r.duplicateHook(v)
+					}
+					continue
+				}
+				if r.atTotalCap() {
+					if collect {
+						leftover = r.appendNew(leftover, seen, vs[i:i+1])
+					}
+					continue
+				}
+				r.pushBuffer(v)
+				continue
+			}
+			if !r.evictOldest {
+				if collect {
+					leftover = r.appendNew(leftover, seen, vs[i:])
+				}
+				if !r.reAddBumps && r.duplicateHook == nil {
+					break
+				}
+				stopped = true
+				continue
+			}
+			// added nodes are newer than the linked ones, so the
+			// oldest is only unlinked once all linked ones are gone
+			oldest := r.oldest
+			r.untrack(oldest)
+			if r.metrics != nil {
+				r.metrics.OnEvict(oldest.v)
+			}
+			if oldest == head {
+				head = head.next
+				if head == nil {
+					tail = nil
+				}
+			} else {
+				r.unlink(oldest)
 			}
-			if !r.buffer.Contains(v) {
-				r.buffer.Push(v)
+		} else if r.atTotalCap() {
+			if collect {
+				leftover = r.appendNew(leftover, seen, vs[i:])
 			}
+			if !r.reAddBumps && r.duplicateHook == nil {
+				break
+			}
+			stopped = true
 			continue
 		}
+		if r.preferBuffered && r.buffer != nil {
+			if b, ok := r.buffer.(removableBuffer[T]); ok {
+				b.Remove(v)
+			}
+		}
 		node := &node[T]{v: v}
-		r.nodes[v] = node
+		r.track(node)
+		added++
 		if head == nil {
 			head = node
 			tail = head
@@ -136,72 +777,1273 @@ func (r *Robin[T]) Add(vs ...T) {
 		tail.next = node
 		tail = node
 	}
+	if added > 0 && r.metrics != nil {
+		r.metrics.OnAdd(added)
+	}
 
+	// values added at the start of a cycle go to its end instead, so
+	// a pinned value keeps leading, and so do values added anywhere
+	// with a stable cursor; re-added values still move to the current
+	// position with a stable cursor, but not ahead of a pinned value
+	next := r.next
 	r.attach(head, tail)
-}
-
-// removes a node from the circular doubly linked list
-func (r *Robin[T]) unlink(node *node[T]) {
-	// reset if removed value was the last
-	if node == node.next {
-		r.next = nil
-		return
+	if next != nil && r.stableCursor {
+		r.next = next
 	}
-
-	node.prev.next = node.next
-	node.next.prev = node.prev
-
-	// advance robin if removed value belonged to next node
-	if node == r.next {
-		r.next = node.next
+	for i := len(bumped) - 1; i >= 0; i-- {
+		if node := bumped[i]; r.nodes[node.v] == node {
+			r.bump(node)
+		}
+	}
+	if next != nil && next == r.pinned {
+		r.next = next
 	}
+	return leftover
 }
 
-// replaces a removed value with a value from the buffer if possible;
-// if not, the node has to be unlinked from the robin
-func (r *Robin[T]) replaceValue(node *node[T]) bool {
-	if r.buffer != nil {
-		if v, ok := r.buffer.Pop(); ok {
-			node.v = v
-			r.nodes[v] = node
-			return true
+// restore tracks the values and attaches them at the current position
+// as they are, without the checks and options applied by add; none of
+// the values may be in the robin
+func (r *Robin[T]) restore(vs []T) {
+	var head, tail *node[T]
+	for _, v := range vs {
+		node := &node[T]{v: v}
+		r.track(node)
+		if head == nil {
+			head = node
+			tail = head
+			continue
 		}
+		node.prev = tail
+		tail.next = node
+		tail = node
 	}
-	return false
+	r.attach(head, tail)
 }
 
-// Remove values from the robin. If the robin is bounded and there is a
-// non-empty buffer, each removed value will be replaced by popping a
-// value from the buffer. Values not in the robin, including values in
-// the buffer, are ignored.
-func (r *Robin[T]) Remove(vs ...T) {
-	for _, v := range vs {
-		if node, ok := r.nodes[v]; ok {
-			delete(r.nodes, v)
-			if !r.replaceValue(node) {
-				r.unlink(node)
-			}
-		}
+// now returns the current time of the clock set by [WithClock]
+func (r *Robin[T]) now() time.Time {
+	if r.clock != nil {
+		return r.clock()
 	}
+	return time.Now()
+}
+
+// sweepIdle removes the values that have been idle for longer than the
+// timeout set by [WithIdleTimeout]
+func (r *Robin[T]) sweepIdle() {
+	if r.idleTimeout <= 0 {
+		return
+	}
+	now := r.now()
+	removed := 0
+	for node := r.oldest; node != nil; {
+		// a replaced node is tracked as the newest again with a fresh
+		// timestamp, so it is safe to keep walking from its successor
+		newer := node.newer
+		if now.Sub(node.extend().lastServed) > r.idleTimeout {
+			r.remove(node)
+			removed++
+		}
+		node = newer
+	}
+	if removed > 0 && r.metrics != nil {
+		r.metrics.OnRemove(removed)
+	}
+}
+
+// bump relinks a node so that a subsequent call to [Next] returns it
+func (r *Robin[T]) bump(node *node[T]) {
+	if node == r.next {
+		return
+	}
+	r.unlink(node)
+	r.cycleStart = nil
+	if r.reversed {
+		r.linkBetween(node, r.next, r.next.next)
+	} else {
+		r.linkBetween(node, r.next.prev, r.next)
+	}
+	r.next = node
+}
+
+// appendNew appends the values that are neither in the robin, in the
+// buffer nor in seen, which holds the values already appended
+func (r *Robin[T]) appendNew(dst []T, seen map[T]struct{}, vs []T) []T {
+	for _, v := range vs {
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		if _, ok := r.nodes[v]; !ok && !r.BufferContains(v) {
+			seen[v] = struct{}{}
+			dst = append(dst, v)
+		}
+	}
+	return dst
+}
+
+// NextEviction returns the value that would be lost if a new value
+// was added, without modifying anything. For a full robin without a
+// buffer and with [WithEvictOldest], it is the oldest value in the
+// robin. For a full robin with a [LIFOBuffer] or [LRUBuffer] that is
+// full too, it is the value the buffer would overwrite. Otherwise,
+// nothing would be lost and the second return value is false.
+func (r *Robin[T]) NextEviction() (T, bool) {
+	if r.maxLen <= 0 || len(r.nodes) < r.maxLen {
+		return *new(T), false
+	}
+	if r.buffer != nil {
+		if b, ok := r.buffer.(overwritingBuffer[T]); ok && !r.atTotalCap() {
+			return b.PeekOverwrite()
+		}
+		return *new(T), false
+	}
+	if r.evictOldest {
+		return r.oldest.v, true
+	}
+	return *new(T), false
+}
+
+// pushes a value to the buffer, reporting the value it overwrites, if
+// any, to the hook set by [WithBufferOverflowHook] and the metrics
+func (r *Robin[T]) pushBuffer(v T) {
+	if r.metrics != nil {
+		r.metrics.OnBufferPush()
+	}
+	if b, ok := r.buffer.(overwritingBuffer[T]); ok {
+		if lost, ok := b.PeekOverwrite(); ok {
+			r.buffer.Push(v)
+			if r.metrics != nil {
+				r.metrics.OnEvict(lost)
+			}
+			if r.overflowHook != nil {
+				r.overflowHook(lost)
+			}
+			return
+		}
+	}
+	r.buffer.Push(v)
+}
+
+// canInsert returns true if a value can be inserted into the robin
+// directly, without any eviction
+func (r *Robin[T]) canInsert(v T) bool {
+	if _, ok := r.nodes[v]; ok || r.BufferContains(v) || r.validate(v) != nil {
+		return false
+	}
+	return (r.maxLen <= 0 || len(r.nodes) < r.maxLen) && !r.atTotalCap()
+}
+
+// links a node between two adjacent nodes of the circular doubly
+// linked list
+func (r *Robin[T]) linkBetween(node, prev, next *node[T]) {
+	node.prev = prev
+	node.next = next
+	prev.next = node
+	next.prev = node
+}
+
+// AddAfter adds a value immediately after an existing value in the
+// rotation. It returns false, adding nothing, if existing is not in
+// the robin, v is already in the robin or the buffer, or the robin is
+// full.
+func (r *Robin[T]) AddAfter(existing, v T) bool {
+	at, ok := r.nodes[existing]
+	if !ok || !r.canInsert(v) {
+		return false
+	}
+	node := &node[T]{v: v}
+	r.track(node)
+	if r.metrics != nil {
+		r.metrics.OnAdd(1)
+	}
+	if r.reversed {
+		r.linkBetween(node, at.prev, at)
+	} else {
+		r.linkBetween(node, at, at.next)
+	}
+	return true
+}
+
+// AddBefore adds a value immediately before an existing value in the
+// rotation. If existing is the value a subsequent call to [Next] would
+// return, the added value is returned instead, unless existing is
+// pinned, in which case the added value ends the rotation. It returns
+// false, adding nothing, if existing is not in the robin, v is already
+// in the robin or the buffer, or the robin is full.
+func (r *Robin[T]) AddBefore(existing, v T) bool {
+	at, ok := r.nodes[existing]
+	if !ok || !r.canInsert(v) {
+		return false
+	}
+	node := &node[T]{v: v}
+	r.track(node)
+	if r.metrics != nil {
+		r.metrics.OnAdd(1)
+	}
+	if r.reversed {
+		r.linkBetween(node, at, at.next)
+	} else {
+		r.linkBetween(node, at.prev, at)
+	}
+	if at == r.next && at != r.pinned && !r.stableCursor {
+		r.next = node
+	}
+	return true
+}
+
+// PromoteBuffer pops up to n values from the buffer and adds them to
+// the end of the rotation, so that they are returned after the values
+// already in the robin. It stops early if the robin becomes full or
+// the buffer empty, and returns the number of values promoted.
+func (r *Robin[T]) PromoteBuffer(n int) int {
+	if r.buffer == nil {
+		return 0
+	}
+
+	var (
+		head     *node[T]
+		tail     *node[T]
+		promoted int
+	)
+
+	for promoted < n && (r.maxLen <= 0 || len(r.nodes) < r.maxLen) {
+		v, ok := r.buffer.Pop()
+		if !ok {
+			break
+		}
+		if r.metrics != nil {
+			r.metrics.OnBufferPop()
+		}
+		if _, ok := r.nodes[v]; ok {
+			continue
+		}
+		node := &node[T]{v: v}
+		r.track(node)
+		promoted++
+		if head == nil {
+			head = node
+			tail = head
+			continue
+		}
+		node.prev = tail
+		tail.next = node
+		tail = node
+	}
+
+	// attaching before the next node makes the promoted values the
+	// last in the rotation as long as the next node is kept
+	next := r.next
+	r.attach(head, tail)
+	if next != nil {
+		r.next = next
+	}
+	if promoted > 0 && r.metrics != nil {
+		r.metrics.OnAdd(promoted)
+	}
+	return promoted
+}
+
+// AddValidated adds values to the robin like [Add], but if any of them
+// fails the validation set by [WithValidator], nothing is added and
+// the first validation error is returned.
+func (r *Robin[T]) AddValidated(vs ...T) error {
+	for _, v := range vs {
+		if err := r.validate(v); err != nil {
+			return err
+		}
+	}
+	r.Add(vs...)
+	return nil
+}
+
+// AddUnique adds values to the robin like [Add], but if any of them is
+// already in the robin or the buffer, or given more than once, nothing
+// is added and an error wrapping [ErrDuplicate] with the first such
+// value is returned.
+func (r *Robin[T]) AddUnique(vs ...T) error {
+	seen := make(map[T]struct{}, len(vs))
+	for _, v := range vs {
+		if _, ok := seen[v]; ok || r.Contains(v) || r.BufferContains(v) {
+			return fmt.Errorf("%w: %v", ErrDuplicate, v)
+		}
+		seen[v] = struct{}{}
+	}
+	r.Add(vs...)
+	return nil
+}
+
+// validate returns [ErrZeroValue] for a zero value if [WithRejectZero]
+// is set, or the error of the validator set by [WithValidator]
+// for a value, if any
+func (r *Robin[T]) validate(v T) error {
+	if r.rejectZero && v == *new(T) {
+		return ErrZeroValue
+	}
+	if r.validator == nil {
+		return nil
+	}
+	return r.validator(v)
+}
+
+// TryAdd adds a value to the robin like [Add], but returns the error
+// of the validator set by [WithValidator] if the value is invalid,
+// [ErrDuplicate] if the value is already in the robin or the buffer
+// and [ErrFull] if the value was neither added nor buffered.
+func (r *Robin[T]) TryAdd(v T) error {
+	if err := r.validate(v); err != nil {
+		return err
+	}
+	if r.Contains(v) || r.BufferContains(v) {
+		return ErrDuplicate
+	}
+	r.Add(v)
+	if !r.Contains(v) && !r.BufferContains(v) {
+		return ErrFull
+	}
+	return nil
+}
+
+// removes a node from the circular doubly linked list
+func (r *Robin[T]) unlink(node *node[T]) {
+	// reset if removed value was the last
+	if node == node.next {
+		r.next = nil
+		return
+	}
+
+	node.prev.next = node.next
+	node.next.prev = node.prev
+
+	// advance robin if removed value belonged to next node
+	if node == r.next {
+		r.next = r.step(node)
+	}
+}
+
+// replaces a removed value with a value from the buffer if possible;
+// if not, the node has to be unlinked from the robin
+func (r *Robin[T]) replaceValue(node *node[T]) bool {
+	if r.buffer != nil {
+		if v, ok := r.buffer.Pop(); ok {
+			removed := node.v
+			node.v = v
+			node.ext = nil
+			r.track(node)
+			if r.metrics != nil {
+				r.metrics.OnBufferPop()
+				r.metrics.OnAdd(1)
+			}
+			if r.replacementHook != nil {
+				r.replacementHook(removed, v)
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// removes a node from the robin, replacing its value from the buffer
+// if possible
+func (r *Robin[T]) remove(node *node[T]) {
+	r.untrack(node)
+	if !r.replaceValue(node) {
+		r.unlink(node)
+	}
+}
+
+// removes a node from the robin without replacing its value from the
+// buffer
+func (r *Robin[T]) retire(node *node[T]) {
+	r.untrack(node)
+	r.unlink(node)
+}
+
+// records a value removed on request in the history set by
+// [WithRemovalHistory]
+func (r *Robin[T]) recordRemoval(v T) {
+	if len(r.history) == 0 {
+		return
+	}
+	r.history[r.historyI] = v
+	r.historyI = (r.historyI + 1) % len(r.history)
+	if r.historyN < len(r.history) {
+		r.historyN++
+	}
+}
+
+// records a value returned by [Next] in the history set by
+// [WithServeHistory]
+func (r *Robin[T]) recordServe(v T, t time.Time) {
+	if r.serveHistory == nil {
+		return
+	}
+	r.serveHistory[r.serveHistoryI] = ServeEvent[T]{Value: v, Time: t}
+	r.serveHistoryI = (r.serveHistoryI + 1) % len(r.serveHistory)
+	if r.serveHistoryN < len(r.serveHistory) {
+		r.serveHistoryN++
+	}
+}
+
+// ServeHistory returns the values most recently returned by [Next],
+// oldest first, see [WithServeHistory].
+func (r *Robin[T]) ServeHistory() []ServeEvent[T] {
+	events := make([]ServeEvent[T], 0, r.serveHistoryN)
+	for i := r.serveHistoryN; i > 0; i-- {
+		events = append(events, r.serveHistory[(r.serveHistoryI-i+len(r.serveHistory))%len(r.serveHistory)])
+	}
+	return events
+}
+
+// LastServed returns the time a value in the robin was last returned
+// by [Next], see [WithServeHistory]. The second return value is false
+// if the value is not in the robin, has not been returned since it was
+// added, or WithServeHistory is not set.
+func (r *Robin[T]) LastServed(v T) (time.Time, bool) {
+	node, ok := r.nodes[v]
+	if !ok || r.serveHistory == nil || node.ext == nil || !node.ext.served {
+		return time.Time{}, false
+	}
+	return node.ext.lastServed, true
+}
+
+// RemovalHistory returns the values most recently removed by [Remove]
+// and its variants, most recent first, see [WithRemovalHistory].
+func (r *Robin[T]) RemovalHistory() []T {
+	vs := make([]T, 0, r.historyN)
+	for i := 1; i <= r.historyN; i++ {
+		vs = append(vs, r.history[(r.historyI-i+len(r.history))%len(r.history)])
+	}
+	return vs
+}
+
+// Undo adds the most recently removed value in the history set by
+// [WithRemovalHistory] back to the robin like [Add] and drops it from
+// the history, so a subsequent call to [Next] returns it unless
+// [WithStableCursor] is set or a pinned value is next, see [Robin.Pin].
+// If the robin is full, nothing is done and the second return value is
+// false. If the value has been added to the robin or the buffer since,
+// it is dropped from the history and the second return value is false.
+func (r *Robin[T]) Undo() (T, bool) {
+	if r.historyN == 0 {
+		return *new(T), false
+	}
+	i := (r.historyI - 1 + len(r.history)) % len(r.history)
+	v := r.history[i]
+	present := r.Contains(v) || r.BufferContains(v)
+	if !present && !r.canInsert(v) {
+		return *new(T), false
+	}
+	r.historyI = i
+	r.historyN--
+	if present {
+		return *new(T), false
+	}
+	r.Add(v)
+	return v, true
+}
+
+// Remove values from the robin. If the robin is bounded and there is a
+// non-empty buffer, each removed value will be replaced by popping a
+// value from the buffer. Values not in the robin, including values in
+// the buffer, are ignored. If [WithImmutableMembers] is set, Remove
+// is a no-op.
+func (r *Robin[T]) Remove(vs ...T) {
+	r.sweepIdle()
+	if r.immutable {
+		return
+	}
+	r.removeValues(vs)
+}
+
+// removes the values on request, reporting them to the metrics
+func (r *Robin[T]) removeValues(vs []T) {
+	removed := 0
+	for _, v := range vs {
+		if node, ok := r.nodes[v]; ok {
+			r.recordRemoval(v)
+			r.remove(node)
+			removed++
+		}
+	}
+	if removed > 0 && r.metrics != nil {
+		r.metrics.OnRemove(removed)
+	}
+}
+
+// Without removes values from the robin like [Remove] and returns the
+// robin, for chaining.
+func (r *Robin[T]) Without(vs ...T) *Robin[T] {
+	r.Remove(vs...)
+	return r
+}
+
+// RemoveSet removes the values of the set from the robin like
+// [Remove], without collecting them in a slice first. A value replacing
+// a removed one from the buffer is removed as well if it is in the set,
+// so no value of the set is left in the robin.
+func (r *Robin[T]) RemoveSet(set map[T]struct{}) {
+	r.sweepIdle()
+	if r.immutable {
+		return
+	}
+	removed := 0
+	for v := range set {
+		node, ok := r.nodes[v]
+		for ok {
+			r.recordRemoval(node.v)
+			r.remove(node)
+			removed++
+			// a replaced node is tracked again with the popped value
+			_, ok = set[node.v]
+			ok = ok && r.nodes[node.v] == node
+		}
+	}
+	if removed > 0 && r.metrics != nil {
+		r.metrics.OnRemove(removed)
+	}
+}
+
+// TryRemove removes a value from the robin like [Remove], but returns
+// [ErrNotFound] if the value is not in the robin and [ErrImmutable]
+// if [WithImmutableMembers] is set.
+func (r *Robin[T]) TryRemove(v T) error {
+	r.sweepIdle()
+	node, ok := r.nodes[v]
+	if !ok {
+		return ErrNotFound
+	}
+	if r.immutable {
+		return ErrImmutable
+	}
+	r.recordRemoval(v)
+	r.remove(node)
+	if r.metrics != nil {
+		r.metrics.OnRemove(1)
+	}
+	return nil
+}
+
+// ReverseDirection reverses the direction of the robin, so that
+// subsequent calls to [Next] traverse the values in the opposite
+// order. Values added afterwards are still returned next, in the
+// order they were added.
+func (r *Robin[T]) ReverseDirection() {
+	r.reversed = !r.reversed
+}
+
+// Direction returns the current direction of the robin.
+func (r *Robin[T]) Direction() Direction {
+	if r.reversed {
+		return Backward
+	}
+	return Forward
+}
+
+// CompareAndRemoveNext removes the value a subsequent call to [Next]
+// would return, but only if it equals expected. It returns false if
+// the robin is empty or the value differs. On success, the value may
+// be replaced from the buffer like with [Remove]. If
+// [WithImmutableMembers] is set, it always returns false.
+func (r *Robin[T]) CompareAndRemoveNext(expected T) bool {
+	if r.immutable || r.next == nil || r.next.v != expected {
+		return false
+	}
+	r.recordRemoval(expected)
+	r.remove(r.next)
+	if r.metrics != nil {
+		r.metrics.OnRemove(1)
+	}
+	return true
 }
 
 // Next returns the next value in the robin. If the robin is empty, the
 // second return value is false.
 func (r *Robin[T]) Next() (T, bool) {
+	return r.nextSkipping(nil)
+}
+
+// pick returns the node a call to Next serves, ignoring the nodes for
+// which skip, if not nil, returns true, or nil if there is none
+func (r *Robin[T]) pick(skip func(*node[T]) bool) *node[T] {
 	if r.next == nil {
+		return nil
+	}
+	if r.wfq {
+		return r.pickWFQ(skip)
+	}
+	if r.minGap > 0 {
+		return r.skipRecent(skip)
+	}
+	if skip == nil {
+		return r.next
+	}
+	node := r.next
+	for i := 0; i < len(r.nodes); i++ {
+		if !skip(node) {
+			return node
+		}
+		node = r.step(node)
+	}
+	return nil
+}
+
+// nextSkipping returns the next value like Next, ignoring the nodes for
+// which skip, if not nil, returns true
+func (r *Robin[T]) nextSkipping(skip func(*node[T]) bool) (T, bool) {
+	r.sweepIdle()
+	node := r.next
+	if skip != nil || r.wfq || r.minGap > 0 {
+		node = r.pick(skip)
+	}
+	if node == nil {
 		return *new(T), false
 	}
-	v := r.next.v
-	r.next = r.next.next
+	if r.wfq {
+		r.advanceWFQ(node)
+	} else if r.minGap > 0 {
+		r.recent[r.recentI] = node.v
+		r.recentI = (r.recentI + 1) % r.minGap
+		if r.recentN < r.minGap {
+			r.recentN++
+		}
+	}
+	v := node.v
+	if r.idleTimeout > 0 || r.serveHistory != nil {
+		now := r.now()
+		ext := node.extend()
+		ext.lastServed = now
+		ext.served = true
+		r.recordServe(v, now)
+	}
+	if r.cycleStart == nil {
+		r.cycleStart = node
+	}
+	r.next = r.step(node)
+	if r.next == r.cycleStart {
+		r.cycles++
+	}
+	if r.countServes || r.maxServes > 0 || r.totalServes > 0 {
+		ext := node.extend()
+		ext.serves++
+		if ext.serves == r.totalServes {
+			r.retire(node)
+			if r.metrics != nil {
+				r.metrics.OnRemove(1)
+			}
+		} else if ext.serves == r.maxServes {
+			r.remove(node)
+			if r.metrics != nil {
+				r.metrics.OnRemove(1)
+			}
+		}
+	}
+	if r.metrics != nil {
+		r.metrics.OnNext(v)
+	}
+	if r.nextHook != nil {
+		r.nextHook(v)
+	}
 	return v, true
 }
 
+// Cycles returns the number of full rotations completed by [Next]
+// since the robin was created or reset. A rotation starts at the value
+// first returned by Next and completes when Next has returned every
+// value once. Adding or removing values, or moving the current
+// position other than by Next, restarts the current rotation at the
+// next value returned.
+func (r *Robin[T]) Cycles() uint64 {
+	return r.cycles
+}
+
+// Mark records the current position, see [NextUntilMark].
+func (r *Robin[T]) Mark() {
+	r.mark = r.next
+}
+
+// NextUntilMark calls [Next] until the current position is back at the
+// position recorded by [Mark] and returns the values in the order they
+// were returned. If the current position is already at the mark, a
+// full rotation is returned. It stops early if the marked value is
+// removed, and returns nil if there is no mark. At most [Len] values
+// are returned.
+func (r *Robin[T]) NextUntilMark() []T {
+	if r.mark == nil {
+		return nil
+	}
+	n := len(r.nodes)
+	vs := make([]T, 0, n)
+	for i := 0; i < n; i++ {
+		v, ok := r.Next()
+		if !ok {
+			break
+		}
+		vs = append(vs, v)
+		if r.mark == nil || r.next == r.mark {
+			break
+		}
+	}
+	return vs
+}
+
+// NextDistinct calls [Next] up to n times and returns the values in
+// the order they were returned. Unlike repeated calls to Next, values
+// already returned are skipped, also by the choice made by [WithWFQ]
+// and [WithMinGap], so at most [Len] values are returned and none of
+// them twice.
+func (r *Robin[T]) NextDistinct(n int) []T {
+	if n > len(r.nodes) {
+		n = len(r.nodes)
+	}
+	if n <= 0 {
+		return nil
+	}
+	vs := make([]T, 0, n)
+	seen := make(map[T]struct{}, n)
+	returned := func(node *node[T]) bool {
+		_, ok := seen[node.v]
+		return ok
+	}
+	for i := 0; i < n; i++ {
+		v, ok := r.nextSkipping(returned)
+		if !ok {
+			break
+		}
+		seen[v] = struct{}{}
+		vs = append(vs, v)
+	}
+	return vs
+}
+
+// NextInto calls [Next] up to len(dst) times, writing the values to
+// dst in the order they were returned, and returns the number of
+// values written. Unlike [NextDistinct], it keeps going around the
+// rotation, so it only writes fewer values if the robin is empty.
+func (r *Robin[T]) NextInto(dst []T) int {
+	for i := range dst {
+		v, ok := r.Next()
+		if !ok {
+			return i
+		}
+		dst[i] = v
+	}
+	return len(dst)
+}
+
+// SampleDistribution calls [Next] n times and returns how many times
+// each value was returned, then moves the cursor back to where it was
+// before sampling, unless that value has since been removed. Serve
+// counts and limits are affected as by any other call to Next.
+func (r *Robin[T]) SampleDistribution(n int) map[T]int {
+	start := r.next
+	counts := make(map[T]int, len(r.nodes))
+	for i := 0; i < n; i++ {
+		v, ok := r.Next()
+		if !ok {
+			break
+		}
+		counts[v]++
+	}
+	if start != nil && r.nodes[start.v] == start {
+		r.next = start
+		r.cycleStart = nil
+	}
+	return counts
+}
+
+// Neighbors returns the values immediately before and after a value
+// in the rotation. For a robin with a single value, both are the value
+// itself. If the value is not in the robin, ok is false.
+func (r *Robin[T]) Neighbors(v T) (prev T, next T, ok bool) {
+	node, ok := r.nodes[v]
+	if !ok {
+		return prev, next, false
+	}
+	if r.reversed {
+		return node.next.v, node.prev.v, true
+	}
+	return node.prev.v, node.next.v, true
+}
+
+// PeekInto writes up to len(dst) of the values subsequent calls to
+// [Next] would return to dst, going around the rotation as needed,
+// without advancing the robin, and returns the number of values
+// written. If the robin is empty, nothing is written. Values that
+// [WithMinGap] or serve limits would skip are included.
+func (r *Robin[T]) PeekInto(dst []T) int {
+	if r.next == nil {
+		return 0
+	}
+	node := r.next
+	for i := range dst {
+		dst[i] = node.v
+		node = r.step(node)
+	}
+	return len(dst)
+}
+
+// PeekPair returns the value a subsequent call to [Next] would return
+// and the value following it, without advancing the robin. For a robin
+// with a single value, both are the same. If the robin is empty, ok is
+// false.
+func (r *Robin[T]) PeekPair() (cur T, next T, ok bool) {
+	if r.next == nil {
+		return cur, next, false
+	}
+	return r.next.v, r.step(r.next).v, true
+}
+
+// skipRecent returns the first node from the current position whose
+// value has not been returned within the last calls to Next set by
+// [WithMinGap], or the first node if there is no such node, ignoring
+// the nodes for which skip, if not nil, returns true
+func (r *Robin[T]) skipRecent(skip func(*node[T]) bool) *node[T] {
+	var first *node[T]
+	node := r.next
+	for i := 0; i < len(r.nodes); i++ {
+		if skip == nil || !skip(node) {
+			recent := false
+			for j := 0; j < r.recentN; j++ {
+				if r.recent[j] == node.v {
+					recent = true
+					break
+				}
+			}
+			if !recent {
+				return node
+			}
+			if first == nil {
+				first = node
+			}
+		}
+		node = r.step(node)
+	}
+	return first
+}
+
+// Pause marks a value in the robin as paused, so that [NextActive]
+// skips it while it keeps its position in the rotation. Paused values
+// still count toward [Len] and are returned by [Next]. It returns
+// false if the value is not in the robin.
+func (r *Robin[T]) Pause(v T) bool {
+	node, ok := r.nodes[v]
+	if ok {
+		node.extend().paused = true
+	}
+	return ok
+}
+
+// Resume unmarks a paused value. It returns false if the value is not
+// in the robin.
+func (r *Robin[T]) Resume(v T) bool {
+	node, ok := r.nodes[v]
+	if ok && node.ext != nil {
+		node.ext.paused = false
+	}
+	return ok
+}
+
+// NextActive returns the next value in the robin that is not paused,
+// see [Pause]. Paused values are left out of the choice made by
+// [WithWFQ] and [WithMinGap] as well. If the robin is empty or all
+// values are paused, the second return value is false.
+func (r *Robin[T]) NextActive() (T, bool) {
+	return r.nextSkipping(func(n *node[T]) bool { return n.paused() })
+}
+
+// SeekRandom moves the current position to a value chosen uniformly
+// at random using rng and returns it, so a subsequent call to [Next]
+// returns the same value. If the robin is empty, the second return
+// value is false. It is O(n).
+func (r *Robin[T]) SeekRandom(rng *rand.Rand) (T, bool) {
+	if r.next == nil {
+		return *new(T), false
+	}
+	for i := rng.Intn(len(r.nodes)); i > 0; i-- {
+		r.next = r.step(r.next)
+	}
+	r.cycleStart = nil
+	return r.next.v, true
+}
+
+// SeekAfter moves the current position to the value following v in
+// the rotation, so a subsequent call to [Next] returns it. For a robin
+// with a single value, that is v itself. It returns false if v is not
+// in the robin.
+func (r *Robin[T]) SeekAfter(v T) bool {
+	node, ok := r.nodes[v]
+	if ok {
+		r.next = r.step(node)
+		r.cycleStart = nil
+	}
+	return ok
+}
+
+// SeekIndex moves the current position to the value at index i in
+// insertion order, where index 0 is the oldest value in the robin, so
+// a subsequent call to [Next] returns it. A value replaced from the
+// buffer counts as newly inserted. It returns false if i is out of
+// range. It is O(n).
+func (r *Robin[T]) SeekIndex(i int) bool {
+	if i < 0 || i >= len(r.nodes) {
+		return false
+	}
+	node := r.oldest
+	for ; i > 0; i-- {
+		node = node.newer
+	}
+	r.next = node
+	r.cycleStart = nil
+	return true
+}
+
+// CursorPosition returns the index in insertion order of the value a
+// subsequent call to [Next] would return, see [SeekIndex]. Robins with
+// the same values added in the same order can share positions. If the
+// robin is empty, -1 is returned. It is O(n).
+func (r *Robin[T]) CursorPosition() int {
+	i := 0
+	for node := r.oldest; node != nil; node = node.newer {
+		if node == r.next {
+			return i
+		}
+		i++
+	}
+	return -1
+}
+
+// SetCursorPosition moves the current position to a position returned
+// by [CursorPosition]. It is the same as [SeekIndex].
+func (r *Robin[T]) SetCursorPosition(i int) bool {
+	return r.SeekIndex(i)
+}
+
+// Reorder relinks the values of the robin into the given order, which
+// must contain each value in the robin exactly once, and moves the
+// current position to the first of them. The nodes are kept, so serve
+// counts and other per-value state are preserved. It returns
+// [ErrNotPermutation] if order is not a permutation of the values.
+func (r *Robin[T]) Reorder(order []T) error {
+	if len(order) != len(r.nodes) {
+		return ErrNotPermutation
+	}
+	nodes := make([]*node[T], len(order))
+	seen := make(map[T]struct{}, len(order))
+	for i, v := range order {
+		node, ok := r.nodes[v]
+		if _, dup := seen[v]; !ok || dup {
+			return ErrNotPermutation
+		}
+		seen[v] = struct{}{}
+		nodes[i] = node
+	}
+	for i, node := range nodes {
+		following := nodes[(i+1)%len(nodes)]
+		if r.reversed {
+			node.prev, following.next = following, node
+		} else {
+			node.next, following.prev = following, node
+		}
+	}
+	if len(nodes) > 0 {
+		r.next = nodes[0]
+	}
+	r.cycleStart = nil
+	return nil
+}
+
+// Pin marks a value as pinned and moves the current position to it,
+// so that every rotation starts with it. Values added while the pinned
+// value is next are added to the end of the rotation instead of being
+// returned next. Only one value can be pinned at a time, and the pin
+// is cleared when the value is removed. It returns false if the value
+// is not in the robin.
+func (r *Robin[T]) Pin(v T) bool {
+	node, ok := r.nodes[v]
+	if ok {
+		r.pinned = node
+		r.next = node
+		r.cycleStart = nil
+	}
+	return ok
+}
+
+// Unpin clears the pinned value, see [Pin].
+func (r *Robin[T]) Unpin() {
+	r.pinned = nil
+}
+
+// values returns the values of the robin in rotation order, starting
+// with the value a subsequent call to [Next] would return
+func (r *Robin[T]) values() []T {
+	if r.next == nil {
+		return nil
+	}
+	vs := make([]T, 0, len(r.nodes))
+	node := r.next
+	for {
+		vs = append(vs, node.v)
+		node = r.step(node)
+		if node == r.next {
+			return vs
+		}
+	}
+}
+
+// Partition splits the values of the robin into two new unbounded
+// robins, one with the values satisfying pred and one with the rest.
+// Both preserve the relative rotation order of the values, starting
+// from the current position. The robin itself is not modified.
+func (r *Robin[T]) Partition(pred func(T) bool) (matching, rest *Robin[T]) {
+	var mvs, rvs []T
+	for _, v := range r.values() {
+		if pred(v) {
+			mvs = append(mvs, v)
+		} else {
+			rvs = append(rvs, v)
+		}
+	}
+	matching = NewUnbounded[T]()
+	matching.Add(mvs...)
+	rest = NewUnbounded[T]()
+	rest.Add(rvs...)
+	return matching, rest
+}
+
+// Fingerprint returns a hash of the values in the robin. It does not
+// depend on the order of the values or the current position, so two
+// robins with the same values have the same fingerprint, while robins
+// with different values have different fingerprints with high
+// probability. Values are hashed with FNV-1a, using their binary
+// representation for strings and numeric types and their formatting
+// otherwise. It is O(n).
+func (r *Robin[T]) Fingerprint() uint64 {
+	var sum uint64
+	for v := range r.nodes {
+		sum += mix64(hashValue(v))
+	}
+	return sum
+}
+
+// ServeCount returns the number of times the value has been returned
+// by [Next] since it was added. The second return value is false if
+// the value is not in the robin or [WithServeCounts] is not set.
+func (r *Robin[T]) ServeCount(v T) (int, bool) {
+	node, ok := r.nodes[v]
+	if !ok || !r.countServes {
+		return 0, false
+	}
+	return node.serves(), true
+}
+
+// LeastServed returns the value with the smallest serve count, see
+// [ServeCount], preferring the oldest value on ties. The second return
+// value is false if the robin is empty or [WithServeCounts] is not
+// set. It is O(n).
+func (r *Robin[T]) LeastServed() (T, bool) {
+	return r.servedBy(func(a, b int) bool { return a < b })
+}
+
+// MostServed returns the value with the largest serve count, see
+// [ServeCount], preferring the oldest value on ties. The second return
+// value is false if the robin is empty or [WithServeCounts] is not
+// set. It is O(n).
+func (r *Robin[T]) MostServed() (T, bool) {
+	return r.servedBy(func(a, b int) bool { return a > b })
+}
+
+// Starved returns the values whose serve count, see [ServeCount], is
+// below the mean serve count by more than the threshold, that is below
+// mean * (1 - threshold), in insertion order. If [WithServeCounts] is
+// not set, nil is returned. It is O(n).
+func (r *Robin[T]) Starved(threshold float64) []T {
+	if !r.countServes || len(r.nodes) == 0 {
+		return nil
+	}
+	total := 0
+	for node := r.oldest; node != nil; node = node.newer {
+		total += node.serves()
+	}
+	limit := float64(total) / float64(len(r.nodes)) * (1 - threshold)
+	var vs []T
+	for node := r.oldest; node != nil; node = node.newer {
+		if float64(node.serves()) < limit {
+			vs = append(vs, node.v)
+		}
+	}
+	return vs
+}
+
+// servedBy returns the oldest value whose serve count is preferred by
+// better over the serve counts of all other values
+func (r *Robin[T]) servedBy(better func(a, b int) bool) (T, bool) {
+	if !r.countServes || r.oldest == nil {
+		return *new(T), false
+	}
+	found := r.oldest
+	for node := found.newer; node != nil; node = node.newer {
+		if better(node.serves(), found.serves()) {
+			found = node
+		}
+	}
+	return found.v, true
+}
+
+// ServeCounts returns the serve counts of all values in the robin,
+// see [ServeCount]. If [WithServeCounts] is not set, nil is returned.
+func (r *Robin[T]) ServeCounts() map[T]int {
+	if !r.countServes {
+		return nil
+	}
+	counts := make(map[T]int, len(r.nodes))
+	for v, node := range r.nodes {
+		counts[v] = node.serves()
+	}
+	return counts
+}
+
+// ToDOT returns a Graphviz DOT representation of the robin, with one
+// node per value, directed next and prev edges between them and the
+// node at the current position highlighted. Nodes are named n0, n1,
+// and so on in rotation order, starting from the current position.
+func (r *Robin[T]) ToDOT() string {
+	var sb strings.Builder
+	sb.WriteString("digraph robin {\n")
+	vs := r.values()
+	for i, v := range vs {
+		label := strconv.Quote(fmt.Sprint(v))
+		if i == 0 {
+			fmt.Fprintf(&sb, "\tn%d [label=%s, style=filled, fillcolor=lightblue];\n", i, label)
+		} else {
+			fmt.Fprintf(&sb, "\tn%d [label=%s];\n", i, label)
+		}
+	}
+	for i := range vs {
+		j := (i + 1) % len(vs)
+		fmt.Fprintf(&sb, "\tn%d -> n%d [label=\"next\"];\n", i, j)
+		fmt.Fprintf(&sb, "\tn%d -> n%d [label=\"prev\", style=dashed];\n", j, i)
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// TryNext returns the next value in the robin like [Next], but
+// returns [ErrEmpty] if the robin is empty.
+func (r *Robin[T]) TryNext() (T, error) {
+	v, ok := r.Next()
+	if !ok {
+		return v, ErrEmpty
+	}
+	return v, nil
+}
+
+// PopBuffer pops a value from the buffer without adding it to the
+// robin. It returns [ErrNoBuffer] if there is no buffer and [ErrEmpty]
+// if the buffer is empty.
+func (r *Robin[T]) PopBuffer() (T, error) {
+	if r.buffer == nil {
+		return *new(T), ErrNoBuffer
+	}
+	v, ok := r.buffer.Pop()
+	if !ok {
+		return v, ErrEmpty
+	}
+	if r.metrics != nil {
+		r.metrics.OnBufferPop()
+	}
+	return v, nil
+}
+
+// IsConsistent checks the internal structure of the robin. It returns
+// true if the values reachable from the current position form a ring
+// with matching next and prev links, every one of them is indexed,
+// and there are as many as indexed values, and if the insertion order
+// covers the same values. It is an O(n) diagnostic.
+func (r *Robin[T]) IsConsistent() bool {
+	if r.next == nil {
+		return len(r.nodes) == 0 && r.oldest == nil && r.newest == nil
+	}
+	n := 0
+	node := r.next
+	for {
+		if n == len(r.nodes) || node.next.prev != node || node.prev.next != node || r.nodes[node.v] != node {
+			return false
+		}
+		n++
+		node = node.next
+		if node == r.next {
+			break
+		}
+	}
+	if n != len(r.nodes) {
+		return false
+	}
+	n = 0
+	for node := r.oldest; node != nil; node = node.newer {
+		if n == len(r.nodes) || r.nodes[node.v] != node {
+			return false
+		}
+		n++
+	}
+	return n == len(r.nodes)
+}
+
 // Contains returns true if the value is in the robin.
 func (r *Robin[T]) Contains(v T) bool {
 	_, ok := r.nodes[v]
 	return ok
 }
 
+// MightContain returns false if the value is definitely not in the
+// robin. If it returns true, the value is in the robin with a
+// probability depending on the size and number of hashes set by
+// [WithBloomIndex]. Without a bloom index it is the same as [Contains].
+func (r *Robin[T]) MightContain(v T) bool {
+	if r.bloom == nil {
+		return r.Contains(v)
+	}
+	return r.bloom.mightContain(v)
+}
+
+// Range returns the values in the robin between lo and hi, inclusive,
+// in sorted order. It requires [WithOrderedIndex], otherwise nil is
+// returned. It is O(log n) plus the number of values returned.
+func (r *Robin[T]) Range(lo, hi T) []T {
+	if r.less == nil {
+		return nil
+	}
+	i := sort.Search(len(r.index), func(i int) bool { return !r.less(r.index[i], lo) })
+	j := sort.Search(len(r.index), func(j int) bool { return r.less(hi, r.index[j]) })
+	if i >= j {
+		return []T{}
+	}
+	vs := make([]T, j-i)
+	copy(vs, r.index[i:j])
+	return vs
+}
+
+// ContainsAll returns true if all values are in the robin.
+func (r *Robin[T]) ContainsAll(vs ...T) bool {
+	for _, v := range vs {
+		if _, ok := r.nodes[v]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsAny returns true if at least one of the values is in the
+// robin.
+func (r *Robin[T]) ContainsAny(vs ...T) bool {
+	for _, v := range vs {
+		if _, ok := r.nodes[v]; ok {
+			return true
+		}
+	}
+	return false
+}
+
 // BufferContains returns true if the value is in the buffer.
 // If there is no buffer, false is returned.
 func (r *Robin[T]) BufferContains(v T) bool {
@@ -216,6 +2058,74 @@ func (r *Robin[T]) Len() int {
 	return len(r.nodes)
 }
 
+// Available returns the number of values that can be added before
+// the robin reaches its maximum length. For an unbounded robin, -1
+// is returned.
+func (r *Robin[T]) Available() int {
+	if r.maxLen <= 0 {
+		return -1
+	}
+	return r.maxLen - len(r.nodes)
+}
+
+// SameTier reports whether two values are in the same tier, where the
+// robin itself is one tier and its buffer, such as an overflow robin
+// set by [WithOverflowRobin], is the other. If either value is in
+// neither tier, ok is false.
+func (r *Robin[T]) SameTier(a, b T) (same bool, ok bool) {
+	aActive, bActive := r.Contains(a), r.Contains(b)
+	if !aActive && !r.BufferContains(a) || !bActive && !r.BufferContains(b) {
+		return false, false
+	}
+	return aActive == bActive, true
+}
+
+// IsFull returns true if the robin is bounded and has reached its
+// maximum length. An unbounded robin is never full.
+func (r *Robin[T]) IsFull() bool {
+	return r.maxLen > 0 && len(r.nodes) >= r.maxLen
+}
+
+// Unbound makes the robin unbounded. The buffer, if any, is drained by
+// adding its values to the robin in the order they are popped, like
+// [Add], and is then no longer used by the robin. The values were
+// accepted when they were buffered, so they are moved as they are,
+// without checks such as [WithValidator] or [WithTotalCap].
+func (r *Robin[T]) Unbound() {
+	r.maxLen = 0
+	if r.buffer == nil {
+		return
+	}
+	var vs []T
+	for {
+		v, ok := r.buffer.Pop()
+		if !ok {
+			break
+		}
+		if r.metrics != nil {
+			r.metrics.OnBufferPop()
+		}
+		if _, ok := r.nodes[v]; !ok {
+			vs = append(vs, v)
+		}
+	}
+	r.buffer = nil
+	next := r.next
+	r.restore(vs)
+	if next != nil && (next == r.pinned || r.stableCursor) {
+		r.next = next
+	}
+	if len(vs) > 0 && r.metrics != nil {
+		r.metrics.OnAdd(len(vs))
+	}
+}
+
+// IsFullyEmpty returns true if both the robin and the buffer are
+// empty.
+func (r *Robin[T]) IsFullyEmpty() bool {
+	return r.Len() == 0 && r.BufferLen() == 0
+}
+
 // BufferLen returns the number of values in the buffer.
 // If there is no buffer, 0 is returned.
 func (r *Robin[T]) BufferLen() int {
@@ -225,13 +2135,111 @@ func (r *Robin[T]) BufferLen() int {
 	return r.buffer.Len()
 }
 
+// BufferCap returns the capacity of the buffer. If there is no buffer,
+// 0 is returned, and if the buffer does not have a fixed capacity, -1
+// is returned.
+func (r *Robin[T]) BufferCap() int {
+	if r.buffer == nil {
+		return 0
+	}
+	if b, ok := r.buffer.(cappedBuffer[T]); ok {
+		return b.Cap()
+	}
+	return -1
+}
+
+// BufferUtilization returns how full the buffer is as a ratio between
+// 0 and 1. If there is no buffer or it does not have a fixed positive
+// capacity, 0 is returned.
+func (r *Robin[T]) BufferUtilization() float64 {
+	c := r.BufferCap()
+	if c <= 0 {
+		return 0
+	}
+	return float64(r.BufferLen()) / float64(c)
+}
+
+// SetBuffer replaces the buffer of a bounded robin and returns the old
+// one, or nil if there was none. If the new buffer has room for all
+// values of the old one, which is always the case if it has no fixed
+// capacity, they are migrated: popped from the old buffer and pushed
+// to the new one in reverse pop order, so a buffer with the same policy
+// pops them in the same order. Otherwise the old buffer is returned
+// with its values. On an unbounded robin, it does nothing and returns
+// nil.
+func (r *Robin[T]) SetBuffer(b Buffer[T]) Buffer[T] {
+	if r.maxLen <= 0 {
+		return nil
+	}
+	old := r.buffer
+	r.buffer = b
+	if old == nil || b == nil {
+		return old
+	}
+	if c, ok := b.(cappedBuffer[T]); ok && c.Cap()-b.Len() < old.Len() {
+		return old
+	}
+	vs := make([]T, 0, old.Len())
+	for {
+		v, ok := old.Pop()
+		if !ok {
+			break
+		}
+		vs = append(vs, v)
+	}
+	for i := len(vs) - 1; i >= 0; i-- {
+		if !b.Contains(vs[i]) {
+			b.Push(vs[i])
+		}
+	}
+	return old
+}
+
+// ResetBuffer resets the buffer, leaving the values and the current
+// position of the robin untouched. If there is no buffer, it is a
+// no-op.
+func (r *Robin[T]) ResetBuffer() {
+	if r.buffer != nil {
+		r.buffer.Reset()
+	}
+}
+
+// Compact rebuilds the map that keeps track of the values in the
+// robin, sized to its current length, releasing any capacity it has
+// retained from removed values. The values, their order and the
+// current position are kept. It is O(n).
+func (r *Robin[T]) Compact() {
+	nodes := make(map[T]*node[T], len(r.nodes))
+	for v, node := range r.nodes {
+		nodes[v] = node
+	}
+	r.nodes = nodes
+}
+
 // Reset the robin. If there is a buffer, it is reset as well.
 func (r *Robin[T]) Reset() {
 	r.next = nil
+	r.historyI = 0
+	r.historyN = 0
+	r.mark = nil
+	r.index = r.index[:0]
+	r.serveHistoryI = 0
+	r.serveHistoryN = 0
+	r.virtual = 0
+	r.cycleStart = nil
+	r.cycles = 0
+	r.pinned = nil
+	r.recentI = 0
+	r.recentN = 0
+	r.oldest = nil
+	r.newest = nil
+	if r.bloom != nil {
+		r.bloom.reset()
+	}
 	if r.buffer == nil {
 		r.nodes = make(map[T]*node[T])
 		return
-	} 
+	}
 	r.buffer.Reset()
 	r.nodes = make(map[T]*node[T], r.maxLen)
 }
@@ -1,12 +1,38 @@
 package robin_test
 
 import (
+	"errors"
+	"math/rand"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/embeage/robin"
 )
 
+// next returns the next n values of the robin
+func next(r *robin.Robin[int], n int) []int {
+	vs := make([]int, 0, n)
+	for i := 0; i < n; i++ {
+		v, ok := r.Next()
+		if !ok {
+			break
+		}
+		vs = append(vs, v)
+	}
+	return vs
+}
+
+// lifoBuffer returns a LIFO buffer with the given values pushed
+func lifoBuffer(capacity int, vs ...int) *robin.LIFOBuffer[int] {
+	b := robin.NewLIFOBuffer[int](capacity)
+	for _, v := range vs {
+		b.Push(v)
+	}
+	return b
+}
+
 func TestRobin(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -101,24 +127,1539 @@ func TestRobin(t *testing.T) {
 			},
 			want: []interface{}{0, 0, false},
 		},
-	}
-
-	for _, tc := range tests {
-		t.Run(tc.name, func(t *testing.T) {
-			var r *robin.Robin[int]
-			if tc.maxLen > 0 {
-				r = robin.NewBounded[int](tc.maxLen, tc.options...)
-			} else {
-				r = robin.NewUnbounded[int]()
-			}
-			var got []interface{}
-			for _, op := range tc.operations {
-				got = append(got, op(r))
-			}
-
-			if !reflect.DeepEqual(got, tc.want) {
-				t.Errorf("Test %q failed: got %v, want %v", tc.name, got, tc.want)
-			}
-		})
+		{
+			name:    "values should be removed after max serves",
+			options: []robin.BoundedOption[int]{robin.WithMaxServes[int](2)},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3); v, _ := r.Next(); return v },
+				func(r *robin.Robin[int]) interface{} { v, _ := r.Next(); return v },
+				func(r *robin.Robin[int]) interface{} { v, _ := r.Next(); return v },
+				func(r *robin.Robin[int]) interface{} { v, _ := r.Next(); return v },
+				func(r *robin.Robin[int]) interface{} { return r.Contains(1) },
+				func(r *robin.Robin[int]) interface{} { v, _ := r.Next(); return v },
+				func(r *robin.Robin[int]) interface{} { v, _ := r.Next(); return v },
+				func(r *robin.Robin[int]) interface{} { return r.Len() },
+				func(r *robin.Robin[int]) interface{} { _, ok := r.Next(); return ok },
+			},
+			want: []interface{}{1, 2, 3, 1, false, 2, 3, 0, false},
+		},
+		{
+			name:    "values removed after max serves should be replaced from buffer",
+			maxLen:  2,
+			options: []robin.BoundedOption[int]{robin.WithBuffer[int](robin.NewLIFOBuffer[int](2)), robin.WithMaxServes[int](2)},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3); v, _ := r.Next(); return v },
+				func(r *robin.Robin[int]) interface{} { v, _ := r.Next(); return v },
+				func(r *robin.Robin[int]) interface{} { v, _ := r.Next(); return v },
+				func(r *robin.Robin[int]) interface{} { return r.Contains(3) },
+				func(r *robin.Robin[int]) interface{} { v, _ := r.Next(); return v },
+				func(r *robin.Robin[int]) interface{} { v, _ := r.Next(); return v },
+				func(r *robin.Robin[int]) interface{} { v, _ := r.Next(); return v },
+				func(r *robin.Robin[int]) interface{} { return r.Len() },
+			},
+			want: []interface{}{1, 2, 1, true, 2, 3, 3, 0},
+		},
+		{
+			name: "available on unbounded robin",
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { return r.Available() },
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3); return r.Available() },
+			},
+			want: []interface{}{-1, -1},
+		},
+		{
+			name:   "available on bounded robin",
+			maxLen: 3,
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { return r.Available() },
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2); return r.Available() },
+				func(r *robin.Robin[int]) interface{} { r.Add(3, 4); return r.Available() },
+				func(r *robin.Robin[int]) interface{} { r.Remove(1); return r.Available() },
+			},
+			want: []interface{}{3, 1, 0, 1},
+		},
+		{
+			name: "partition should split values preserving rotation order",
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} {
+					r.Add(1, 2, 3, 4, 5, 6)
+					r.Next()
+					even, odd := r.Partition(func(v int) bool { return v%2 == 0 })
+					return []interface{}{next(even, 3), next(odd, 3)}
+				},
+				func(r *robin.Robin[int]) interface{} { return r.Len() },
+				func(r *robin.Robin[int]) interface{} { return next(r, 6) },
+			},
+			want: []interface{}{[]interface{}{[]int{2, 4, 6}, []int{3, 5, 1}}, 6, []int{2, 3, 4, 5, 6, 1}},
+		},
+		{
+			name:    "adding to full bounded robin with evict oldest should evict oldest values",
+			maxLen:  3,
+			options: []robin.BoundedOption[int]{robin.WithEvictOldest[int]()},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3, 4); return r.Contains(1) },
+				func(r *robin.Robin[int]) interface{} { return next(r, 3) },
+				func(r *robin.Robin[int]) interface{} { r.Add(5, 6); return r.Len() },
+				func(r *robin.Robin[int]) interface{} { return next(r, 3) },
+				func(r *robin.Robin[int]) interface{} { r.Add(7, 8, 9, 10); return next(r, 3) },
+				func(r *robin.Robin[int]) interface{} { return r.Contains(7) },
+			},
+			want: []interface{}{false, []int{2, 3, 4}, 3, []int{5, 6, 4}, []int{8, 9, 10}, false},
+		},
+		{
+			name: "next active should skip paused values",
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3); return r.Pause(2) },
+				func(r *robin.Robin[int]) interface{} { return r.Pause(4) },
+				func(r *robin.Robin[int]) interface{} { v, _ := r.NextActive(); return v },
+				func(r *robin.Robin[int]) interface{} { v, _ := r.NextActive(); return v },
+				func(r *robin.Robin[int]) interface{} { v, _ := r.NextActive(); return v },
+				func(r *robin.Robin[int]) interface{} { return r.Len() },
+				func(r *robin.Robin[int]) interface{} { return r.Resume(2) },
+				func(r *robin.Robin[int]) interface{} { v, _ := r.NextActive(); return v },
+				func(r *robin.Robin[int]) interface{} { v, _ := r.NextActive(); return v },
+			},
+			want: []interface{}{true, false, 1, 3, 1, 3, true, 2, 3},
+		},
+		{
+			name:    "next active with wfq should skip paused values",
+			options: []robin.BoundedOption[int]{robin.WithWFQ[int]()},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2); r.SetWeight(1, 10); return r.Pause(1) },
+				func(r *robin.Robin[int]) interface{} { v, _ := r.NextActive(); return v },
+				func(r *robin.Robin[int]) interface{} { v, _ := r.NextActive(); return v },
+				func(r *robin.Robin[int]) interface{} { v, _ := r.Next(); return v },
+			},
+			want: []interface{}{true, 2, 2, 1},
+		},
+		{
+			name:    "next active with min gap should skip paused values",
+			options: []robin.BoundedOption[int]{robin.WithMinGap[int](1)},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2); return r.Pause(2) },
+				func(r *robin.Robin[int]) interface{} { v, _ := r.NextActive(); return v },
+				func(r *robin.Robin[int]) interface{} { v, _ := r.NextActive(); return v },
+			},
+			want: []interface{}{true, 1, 1},
+		},
+		{
+			name: "next active with all values paused",
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { _, ok := r.NextActive(); return ok },
+				func(r *robin.Robin[int]) interface{} {
+					r.Add(1, 2)
+					r.Pause(1)
+					r.Pause(2)
+					_, ok := r.NextActive()
+					return ok
+				},
+				func(r *robin.Robin[int]) interface{} { v, _ := r.Next(); return v },
+			},
+			want: []interface{}{false, false, 1},
+		},
+		{
+			name: "compare and remove next",
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { return r.CompareAndRemoveNext(1) },
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3); return r.CompareAndRemoveNext(2) },
+				func(r *robin.Robin[int]) interface{} { return r.Len() },
+				func(r *robin.Robin[int]) interface{} { return r.CompareAndRemoveNext(1) },
+				func(r *robin.Robin[int]) interface{} { return r.Contains(1) },
+				func(r *robin.Robin[int]) interface{} { v, _ := r.Next(); return v },
+			},
+			want: []interface{}{false, false, 3, true, false, 2},
+		},
+		{
+			name:    "compare and remove next should replace from buffer",
+			maxLen:  2,
+			options: []robin.BoundedOption[int]{robin.WithBuffer[int](robin.NewLIFOBuffer[int](2))},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3); return r.CompareAndRemoveNext(1) },
+				func(r *robin.Robin[int]) interface{} { return r.BufferLen() },
+				func(r *robin.Robin[int]) interface{} { return next(r, 2) },
+			},
+			want: []interface{}{true, 0, []int{3, 2}},
+		},
+		{
+			name:    "removing with immutable members should be no-op",
+			options: []robin.BoundedOption[int]{robin.WithImmutableMembers[int]()},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3); r.Remove(1, 2); return r.Len() },
+				func(r *robin.Robin[int]) interface{} { return r.CompareAndRemoveNext(1) },
+				func(r *robin.Robin[int]) interface{} { r.Add(4); return r.Len() },
+				func(r *robin.Robin[int]) interface{} { return next(r, 4) },
+			},
+			want: []interface{}{3, false, 4, []int{4, 1, 2, 3}},
+		},
+		{
+			name: "fingerprint should depend on values only",
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} {
+					return r.Fingerprint() == robin.NewUnbounded[int]().Fingerprint()
+				},
+				func(r *robin.Robin[int]) interface{} {
+					r.Add(1, 2, 3)
+					r.Next()
+					other := robin.NewUnbounded[int]()
+					other.Add(3, 1, 2)
+					return r.Fingerprint() == other.Fingerprint()
+				},
+				func(r *robin.Robin[int]) interface{} {
+					other := robin.NewUnbounded[int]()
+					other.Add(1, 2, 4)
+					return r.Fingerprint() == other.Fingerprint()
+				},
+				func(r *robin.Robin[int]) interface{} {
+					other := robin.NewUnbounded[int]()
+					other.Add(1, 2)
+					return r.Fingerprint() == other.Fingerprint()
+				},
+			},
+			want: []interface{}{true, true, false, false},
+		},
+		{
+			name:    "serve counts should count next calls per value",
+			options: []robin.BoundedOption[int]{robin.WithServeCounts[int]()},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3); next(r, 5); return r.ServeCounts() },
+				func(r *robin.Robin[int]) interface{} { n, _ := r.ServeCount(1); return n },
+				func(r *robin.Robin[int]) interface{} { _, ok := r.ServeCount(4); return ok },
+				func(r *robin.Robin[int]) interface{} { r.Remove(1); r.Add(1); n, _ := r.ServeCount(1); return n },
+				func(r *robin.Robin[int]) interface{} { r.Reset(); r.Add(2); return r.ServeCounts() },
+			},
+			want: []interface{}{map[int]int{1: 2, 2: 2, 3: 1}, 2, false, 0, map[int]int{2: 0}},
+		},
+		{
+			name: "serve counts without option",
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1); r.Next(); _, ok := r.ServeCount(1); return ok },
+				func(r *robin.Robin[int]) interface{} { return r.ServeCounts() == nil },
+			},
+			want: []interface{}{false, true},
+		},
+		{
+			name:   "adding buffered value with prefer buffered should move it to robin",
+			maxLen: 3,
+			options: []robin.BoundedOption[int]{
+				robin.WithBuffer[int](lifoBuffer(2, 4, 5)),
+				robin.WithPreferBuffered[int](),
+			},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 4); return r.Contains(4) },
+				func(r *robin.Robin[int]) interface{} { return r.BufferContains(4) },
+				func(r *robin.Robin[int]) interface{} { return r.BufferLen() },
+				func(r *robin.Robin[int]) interface{} { r.Add(2, 5); return r.Contains(5) },
+				func(r *robin.Robin[int]) interface{} { return r.BufferContains(5) },
+			},
+			want: []interface{}{true, false, 1, false, true},
+		},
+		{
+			name: "contains all and contains any",
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3); return r.ContainsAll(1, 2, 3) },
+				func(r *robin.Robin[int]) interface{} { return r.ContainsAll(1, 4) },
+				func(r *robin.Robin[int]) interface{} { return r.ContainsAll(4, 5) },
+				func(r *robin.Robin[int]) interface{} { return r.ContainsAny(1, 2, 3) },
+				func(r *robin.Robin[int]) interface{} { return r.ContainsAny(1, 4) },
+				func(r *robin.Robin[int]) interface{} { return r.ContainsAny(4, 5) },
+			},
+			want: []interface{}{true, false, false, true, true, false},
+		},
+		{
+			name: "reversing direction should traverse the opposite way",
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3); return r.Direction() },
+				func(r *robin.Robin[int]) interface{} { return next(r, 1) },
+				func(r *robin.Robin[int]) interface{} { r.ReverseDirection(); return r.Direction() },
+				func(r *robin.Robin[int]) interface{} { return next(r, 4) },
+				func(r *robin.Robin[int]) interface{} { r.Add(4, 5); return next(r, 5) },
+				func(r *robin.Robin[int]) interface{} { r.Remove(2); return next(r, 4) },
+				func(r *robin.Robin[int]) interface{} { r.ReverseDirection(); return next(r, 4) },
+			},
+			want: []interface{}{
+				robin.Forward, []int{1}, robin.Backward, []int{2, 1, 3, 2},
+				[]int{4, 5, 1, 3, 2}, []int{4, 5, 1, 3}, []int{4, 3, 1, 5},
+			},
+		},
+		{
+			name:    "reset buffer should only reset buffer",
+			maxLen:  2,
+			options: []robin.BoundedOption[int]{robin.WithBuffer[int](robin.NewLIFOBuffer[int](2))},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3, 4); r.Next(); return r.BufferLen() },
+				func(r *robin.Robin[int]) interface{} { r.ResetBuffer(); return r.BufferLen() },
+				func(r *robin.Robin[int]) interface{} { return r.Len() },
+				func(r *robin.Robin[int]) interface{} { return next(r, 3) },
+			},
+			want: []interface{}{2, 0, 2, []int{2, 1, 2}},
+		},
+		{
+			name: "reset buffer without buffer should be no-op",
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2); r.ResetBuffer(); return next(r, 2) },
+			},
+			want: []interface{}{[]int{1, 2}},
+		},
+		{
+			name:   "adding beyond total cap should drop values",
+			maxLen: 2,
+			options: []robin.BoundedOption[int]{
+				robin.WithBuffer[int](robin.NewLIFOBuffer[int](3)),
+				robin.WithTotalCap[int](4),
+			},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3, 4, 5, 6); return r.Len() },
+				func(r *robin.Robin[int]) interface{} { return r.BufferLen() },
+				func(r *robin.Robin[int]) interface{} { return r.BufferContains(5) },
+				func(r *robin.Robin[int]) interface{} { r.Remove(1); r.Add(7); return r.BufferContains(7) },
+				func(r *robin.Robin[int]) interface{} { return r.Len() + r.BufferLen() },
+			},
+			want: []interface{}{2, 2, false, true, 4},
+		},
+		{
+			name:    "adding beyond total cap should drop values in unbounded robin",
+			options: []robin.BoundedOption[int]{robin.WithTotalCap[int](2)},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3); return r.Len() },
+				func(r *robin.Robin[int]) interface{} { return r.Contains(3) },
+			},
+			want: []interface{}{2, false},
+		},
+		{
+			name:    "next eviction with evict oldest",
+			maxLen:  3,
+			options: []robin.BoundedOption[int]{robin.WithEvictOldest[int]()},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2); _, ok := r.NextEviction(); return ok },
+				func(r *robin.Robin[int]) interface{} { r.Add(3); v, _ := r.NextEviction(); return v },
+				func(r *robin.Robin[int]) interface{} { r.Add(4); return r.Contains(1) },
+				func(r *robin.Robin[int]) interface{} { v, _ := r.NextEviction(); return v },
+			},
+			want: []interface{}{false, 1, false, 2},
+		},
+		{
+			name:    "next eviction with overwriting buffer",
+			maxLen:  2,
+			options: []robin.BoundedOption[int]{robin.WithBuffer[int](robin.NewLIFOBuffer[int](2))},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3); _, ok := r.NextEviction(); return ok },
+				func(r *robin.Robin[int]) interface{} { r.Add(4); v, _ := r.NextEviction(); return v },
+				func(r *robin.Robin[int]) interface{} { r.Add(5); return r.BufferContains(3) },
+				func(r *robin.Robin[int]) interface{} { v, _ := r.NextEviction(); return v },
+			},
+			want: []interface{}{false, 3, false, 4},
+		},
+		{
+			name:   "next eviction without eviction",
+			maxLen: 1,
+			options: []robin.BoundedOption[int]{
+				robin.WithBuffer[int](robin.NewLIFOBuffer[int](1, robin.WithRejectOnFull[int]())),
+			},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2); _, ok := r.NextEviction(); return ok },
+			},
+			want: []interface{}{false},
+		},
+		{
+			name: "seek index should use insertion order",
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { return r.SeekIndex(0) },
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2); r.Next(); r.Add(3); return r.SeekIndex(0) },
+				func(r *robin.Robin[int]) interface{} { return next(r, 3) },
+				func(r *robin.Robin[int]) interface{} { return r.SeekIndex(2) },
+				func(r *robin.Robin[int]) interface{} { return next(r, 3) },
+				func(r *robin.Robin[int]) interface{} { return r.SeekIndex(3) },
+				func(r *robin.Robin[int]) interface{} { return r.SeekIndex(-1) },
+				func(r *robin.Robin[int]) interface{} { return next(r, 1) },
+			},
+			want: []interface{}{false, true, []int{1, 3, 2}, true, []int{3, 2, 1}, false, false, []int{3}},
+		},
+		{
+			name:    "min gap should skip recently returned values",
+			options: []robin.BoundedOption[int]{robin.WithMinGap[int](2)},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3); return next(r, 1) },
+				func(r *robin.Robin[int]) interface{} { r.Remove(1); r.Add(1); return next(r, 4) },
+				func(r *robin.Robin[int]) interface{} { r.Remove(3); r.Add(3); return next(r, 1) },
+			},
+			want: []interface{}{[]int{1}, []int{2, 3, 1, 2}, []int{3}},
+		},
+		{
+			name:    "min gap cannot be honored with too few values",
+			options: []robin.BoundedOption[int]{robin.WithMinGap[int](2)},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2); return next(r, 4) },
+			},
+			want: []interface{}{[]int{1, 2, 1, 2}},
+		},
+		{
+			name:    "promoted buffer values should be added at the end of the rotation",
+			maxLen:  4,
+			options: []robin.BoundedOption[int]{robin.WithBuffer[int](lifoBuffer(3, 5, 6, 7))},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2); r.Next(); return r.PromoteBuffer(3) },
+				func(r *robin.Robin[int]) interface{} { return r.BufferLen() },
+				func(r *robin.Robin[int]) interface{} { return next(r, 4) },
+				func(r *robin.Robin[int]) interface{} { return r.PromoteBuffer(1) },
+			},
+			want: []interface{}{2, 1, []int{2, 1, 7, 6}, 0},
+		},
+		{
+			name:    "promote buffer on empty robin",
+			maxLen:  4,
+			options: []robin.BoundedOption[int]{robin.WithBuffer[int](lifoBuffer(3, 5, 6))},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { return r.PromoteBuffer(1) },
+				func(r *robin.Robin[int]) interface{} { r.Add(1); return r.PromoteBuffer(3) },
+				func(r *robin.Robin[int]) interface{} { return next(r, 3) },
+			},
+			want: []interface{}{1, 1, []int{1, 6, 5}},
+		},
+		{
+			name: "peek pair should not advance robin",
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { a, b, ok := r.PeekPair(); return []interface{}{a, b, ok} },
+				func(r *robin.Robin[int]) interface{} {
+					r.Add(1)
+					a, b, ok := r.PeekPair()
+					return []interface{}{a, b, ok}
+				},
+				func(r *robin.Robin[int]) interface{} {
+					r.Add(2, 3)
+					a, b, ok := r.PeekPair()
+					return []interface{}{a, b, ok}
+				},
+				func(r *robin.Robin[int]) interface{} {
+					r.Next()
+					a, b, ok := r.PeekPair()
+					return []interface{}{a, b, ok}
+				},
+				func(r *robin.Robin[int]) interface{} { return next(r, 1) },
+			},
+			want: []interface{}{
+				[]interface{}{0, 0, false}, []interface{}{1, 1, true},
+				[]interface{}{2, 3, true}, []interface{}{3, 1, true}, []int{3},
+			},
+		},
+		{
+			name:   "add after should insert after existing value",
+			maxLen: 5,
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3); return r.AddAfter(1, 4) },
+				func(r *robin.Robin[int]) interface{} { return r.AddAfter(2, 5) },
+				func(r *robin.Robin[int]) interface{} { return next(r, 5) },
+				func(r *robin.Robin[int]) interface{} { return r.AddAfter(6, 7) },
+				func(r *robin.Robin[int]) interface{} { return r.AddAfter(1, 2) },
+				func(r *robin.Robin[int]) interface{} { r.Remove(5); return r.AddAfter(3, 6) },
+				func(r *robin.Robin[int]) interface{} { return r.AddAfter(3, 7) },
+				func(r *robin.Robin[int]) interface{} { return next(r, 5) },
+			},
+			want: []interface{}{true, true, []int{1, 4, 2, 5, 3}, false, false, true, false, []int{1, 4, 2, 3, 6}},
+		},
+		{
+			name:   "add before should insert before existing value",
+			maxLen: 5,
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3); return r.AddBefore(1, 4) },
+				func(r *robin.Robin[int]) interface{} { return next(r, 1) },
+				func(r *robin.Robin[int]) interface{} { return r.AddBefore(3, 5) },
+				func(r *robin.Robin[int]) interface{} { return next(r, 5) },
+				func(r *robin.Robin[int]) interface{} { return r.AddBefore(6, 7) },
+				func(r *robin.Robin[int]) interface{} { return r.AddBefore(1, 2) },
+				func(r *robin.Robin[int]) interface{} { return r.AddBefore(1, 6) },
+			},
+			want: []interface{}{true, []int{4}, true, []int{1, 2, 5, 3, 4}, false, false, false},
+		},
+		{
+			name:    "values should be retired after total serves without buffer replacement",
+			maxLen:  2,
+			options: []robin.BoundedOption[int]{robin.WithBuffer[int](robin.NewLIFOBuffer[int](1)), robin.WithTotalServes[int](3)},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3); return next(r, 6) },
+				func(r *robin.Robin[int]) interface{} { return r.Len() },
+				func(r *robin.Robin[int]) interface{} { return r.BufferContains(3) },
+				func(r *robin.Robin[int]) interface{} { _, ok := r.Next(); return ok },
+			},
+			want: []interface{}{[]int{1, 2, 1, 2, 1, 2}, 0, true, false},
+		},
+		{
+			name:   "add slice should return values that did not fit",
+			maxLen: 3,
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { return r.AddSlice([]int{1, 2, 1}) },
+				func(r *robin.Robin[int]) interface{} { return r.AddSlice([]int{2, 3, 4, 1, 5}) },
+				func(r *robin.Robin[int]) interface{} { return next(r, 3) },
+				func(r *robin.Robin[int]) interface{} { return r.AddSlice([]int{6}) },
+			},
+			want: []interface{}{[]int(nil), []int{4, 5}, []int{3, 1, 2}, []int{6}},
+		},
+		{
+			name:   "add slice should return repeated leftover values once",
+			maxLen: 2,
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { return r.AddSlice([]int{1, 2, 1, 3, 4, 3}) },
+			},
+			want: []interface{}{[]int{3, 4}},
+		},
+		{
+			name:   "add slice with buffer should return values beyond total cap",
+			maxLen: 1,
+			options: []robin.BoundedOption[int]{
+				robin.WithBuffer[int](robin.NewLIFOBuffer[int](2)),
+				robin.WithTotalCap[int](2),
+			},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { return r.AddSlice([]int{1, 2, 2, 3, 3}) },
+				func(r *robin.Robin[int]) interface{} { return r.BufferContains(2) },
+			},
+			want: []interface{}{[]int{3}, true},
+		},
+		{
+			name: "next distinct should cap at length",
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { return r.NextDistinct(2) },
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3, 4); return r.NextDistinct(2) },
+				func(r *robin.Robin[int]) interface{} { return next(r, 1) },
+				func(r *robin.Robin[int]) interface{} { return r.NextDistinct(4) },
+				func(r *robin.Robin[int]) interface{} { return next(r, 1) },
+				func(r *robin.Robin[int]) interface{} { return r.NextDistinct(10) },
+				func(r *robin.Robin[int]) interface{} { return next(r, 1) },
+			},
+			want: []interface{}{
+				[]int(nil), []int{1, 2}, []int{3}, []int{4, 1, 2, 3},
+				[]int{4}, []int{1, 2, 3, 4}, []int{1},
+			},
+		},
+		{
+			name:    "next distinct with wfq should not repeat heavy values",
+			options: []robin.BoundedOption[int]{robin.WithWFQ[int]()},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3); return r.SetWeight(1, 10) },
+				func(r *robin.Robin[int]) interface{} { return r.NextDistinct(3) },
+				func(r *robin.Robin[int]) interface{} { return r.NextDistinct(2) },
+			},
+			want: []interface{}{true, []int{1, 2, 3}, []int{1, 2}},
+		},
+		{
+			name: "pinned value should lead every rotation",
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3, 4); r.Next(); return r.Pin(3) },
+				func(r *robin.Robin[int]) interface{} { return r.Pin(5) },
+				func(r *robin.Robin[int]) interface{} { return next(r, 8) },
+				func(r *robin.Robin[int]) interface{} { r.Add(5, 6); return next(r, 7) },
+				func(r *robin.Robin[int]) interface{} { r.Unpin(); r.Next(); r.Add(7); return next(r, 1) },
+			},
+			want: []interface{}{true, false, []int{3, 4, 1, 2, 3, 4, 1, 2}, []int{3, 4, 1, 2, 5, 6, 3}, []int{7}},
+		},
+		{
+			name: "adding before pinned value should keep it leading",
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3); r.Pin(1); return r.AddBefore(1, 9) },
+				func(r *robin.Robin[int]) interface{} { return next(r, 5) },
+			},
+			want: []interface{}{true, []int{1, 2, 3, 9, 1}},
+		},
+		{
+			name: "removing pinned value should unpin it",
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} {
+					r.Add(1, 2)
+					r.Pin(2)
+					r.Remove(2)
+					r.Add(2)
+					r.Add(3)
+					return next(r, 3)
+				},
+			},
+			want: []interface{}{[]int{3, 2, 1}},
+		},
+		{
+			name:    "undo should add back removed values",
+			maxLen:  3,
+			options: []robin.BoundedOption[int]{robin.WithRemovalHistory[int](2)},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { _, ok := r.Undo(); return ok },
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3); r.Remove(1, 2, 3); return r.RemovalHistory() },
+				func(r *robin.Robin[int]) interface{} { r.Add(4, 5); v, ok := r.Undo(); return []interface{}{v, ok} },
+				func(r *robin.Robin[int]) interface{} { _, ok := r.Undo(); return ok },
+				func(r *robin.Robin[int]) interface{} { return r.RemovalHistory() },
+				func(r *robin.Robin[int]) interface{} { r.Remove(4); v, ok := r.Undo(); return []interface{}{v, ok} },
+				func(r *robin.Robin[int]) interface{} { return next(r, 3) },
+				func(r *robin.Robin[int]) interface{} { return r.RemovalHistory() },
+			},
+			want: []interface{}{
+				false, []int{3, 2}, []interface{}{3, true}, false, []int{2},
+				[]interface{}{4, true}, []int{4, 3, 5}, []int{2},
+			},
+		},
+		{
+			name: "undo with stable cursor should add value to the end",
+			options: []robin.BoundedOption[int]{
+				robin.WithRemovalHistory[int](1),
+				robin.WithStableCursor[int](),
+			},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3); r.Remove(2); v, _ := r.Undo(); return v },
+				func(r *robin.Robin[int]) interface{} { return next(r, 3) },
+			},
+			want: []interface{}{2, []int{1, 3, 2}},
+		},
+		{
+			name:    "undo with pinned value should keep it leading",
+			options: []robin.BoundedOption[int]{robin.WithRemovalHistory[int](1)},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} {
+					r.Add(1, 2, 3)
+					r.Pin(1)
+					r.Remove(2)
+					v, _ := r.Undo()
+					return v
+				},
+				func(r *robin.Robin[int]) interface{} { return next(r, 3) },
+			},
+			want: []interface{}{2, []int{1, 3, 2}},
+		},
+		{
+			name:    "undo should drop values added since removal",
+			options: []robin.BoundedOption[int]{robin.WithRemovalHistory[int](2)},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} {
+					r.Add(1, 2)
+					r.Remove(1)
+					r.Add(1)
+					_, ok := r.Undo()
+					return ok
+				},
+				func(r *robin.Robin[int]) interface{} { return r.RemovalHistory() },
+			},
+			want: []interface{}{false, []int{}},
+		},
+		{
+			name: "sample distribution should be even and keep cursor",
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3); r.Next(); return r.SampleDistribution(300) },
+				func(r *robin.Robin[int]) interface{} { return r.SampleDistribution(4) },
+				func(r *robin.Robin[int]) interface{} { return next(r, 3) },
+			},
+			want: []interface{}{
+				map[int]int{1: 100, 2: 100, 3: 100},
+				map[int]int{1: 1, 2: 2, 3: 1},
+				[]int{2, 3, 1},
+			},
+		},
+		{
+			name:    "reorder should relink values in the given order",
+			options: []robin.BoundedOption[int]{robin.WithServeCounts[int]()},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} {
+					r.Add(1, 2, 3, 4)
+					r.Next()
+					return r.Reorder([]int{3, 1, 4, 2})
+				},
+				func(r *robin.Robin[int]) interface{} { return next(r, 5) },
+				func(r *robin.Robin[int]) interface{} { n, _ := r.ServeCount(1); return n },
+				func(r *robin.Robin[int]) interface{} { r.ReverseDirection(); return r.Reorder([]int{4, 3, 2, 1}) },
+				func(r *robin.Robin[int]) interface{} { return next(r, 5) },
+			},
+			want: []interface{}{nil, []int{3, 1, 4, 2, 3}, 2, nil, []int{4, 3, 2, 1, 4}},
+		},
+		{
+			name: "reorder should reject orders that are not permutations",
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3); return r.Reorder([]int{1, 2}) },
+				func(r *robin.Robin[int]) interface{} { return r.Reorder([]int{1, 2, 2}) },
+				func(r *robin.Robin[int]) interface{} { return r.Reorder([]int{1, 2, 4}) },
+				func(r *robin.Robin[int]) interface{} { return next(r, 3) },
+			},
+			want: []interface{}{robin.ErrNotPermutation, robin.ErrNotPermutation, robin.ErrNotPermutation, []int{1, 2, 3}},
+		},
+		{
+			name: "cursor position should be restorable",
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { return r.CursorPosition() },
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3, 4); next(r, 2); return r.CursorPosition() },
+				func(r *robin.Robin[int]) interface{} { next(r, 3); return r.SetCursorPosition(2) },
+				func(r *robin.Robin[int]) interface{} { return next(r, 3) },
+				func(r *robin.Robin[int]) interface{} { return r.SetCursorPosition(4) },
+			},
+			want: []interface{}{-1, 2, true, []int{3, 4, 1}, false},
+		},
+		{
+			name:    "re-adding with bumps should move values to next",
+			maxLen:  4,
+			options: []robin.BoundedOption[int]{robin.WithReAddBumps[int]()},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3, 4); r.Next(); r.Add(3); return next(r, 4) },
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 4); return next(r, 4) },
+				func(r *robin.Robin[int]) interface{} { r.Remove(2); r.Add(5, 4); return next(r, 4) },
+				func(r *robin.Robin[int]) interface{} { r.ReverseDirection(); r.Add(3); return next(r, 4) },
+				func(r *robin.Robin[int]) interface{} { return r.IsConsistent() },
+			},
+			want: []interface{}{
+				[]int{3, 2, 4, 1}, []int{1, 4, 3, 2}, []int{4, 5, 1, 3}, []int{3, 4, 1, 5}, true,
+			},
+		},
+		{
+			name:    "re-adding with bumps to full robin should still move values",
+			maxLen:  3,
+			options: []robin.BoundedOption[int]{robin.WithReAddBumps[int]()},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3); return r.AddSlice([]int{4, 3}) },
+				func(r *robin.Robin[int]) interface{} { return next(r, 3) },
+			},
+			want: []interface{}{[]int{4}, []int{3, 1, 2}},
+		},
+		{
+			name: "re-adding with bumps and stable cursor should move values to next",
+			options: []robin.BoundedOption[int]{
+				robin.WithReAddBumps[int](),
+				robin.WithStableCursor[int](),
+			},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3); r.Next(); r.Add(3, 5); return next(r, 4) },
+			},
+			want: []interface{}{[]int{3, 2, 1, 5}},
+		},
+		{
+			name: "re-adding without bumps should be no-op",
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3, 4); r.Next(); r.Add(3); return next(r, 4) },
+			},
+			want: []interface{}{[]int{2, 3, 4, 1}},
+		},
+		{
+			name: "cycles should count full rotations",
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3); next(r, 2); return r.Cycles() },
+				func(r *robin.Robin[int]) interface{} { next(r, 1); return r.Cycles() },
+				func(r *robin.Robin[int]) interface{} { next(r, 7); return r.Cycles() },
+				func(r *robin.Robin[int]) interface{} { r.Add(4); next(r, 3); return r.Cycles() },
+				func(r *robin.Robin[int]) interface{} { next(r, 1); return r.Cycles() },
+				func(r *robin.Robin[int]) interface{} { r.Reset(); return r.Cycles() },
+			},
+			want: []interface{}{uint64(0), uint64(1), uint64(3), uint64(3), uint64(4), uint64(0)},
+		},
+		{
+			name: "cycles should restart the rotation when seeking",
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} {
+					r.Add(1, 2, 3)
+					next(r, 1)
+					r.SeekAfter(2)
+					next(r, 2)
+					return r.Cycles()
+				},
+				func(r *robin.Robin[int]) interface{} { next(r, 1); return r.Cycles() },
+				func(r *robin.Robin[int]) interface{} { next(r, 1); r.SeekIndex(0); next(r, 2); return r.Cycles() },
+				func(r *robin.Robin[int]) interface{} { next(r, 1); return r.Cycles() },
+				func(r *robin.Robin[int]) interface{} { next(r, 1); r.Pin(1); next(r, 2); return r.Cycles() },
+				func(r *robin.Robin[int]) interface{} { next(r, 1); return r.Cycles() },
+			},
+			want: []interface{}{uint64(0), uint64(1), uint64(1), uint64(2), uint64(2), uint64(3)},
+		},
+		{
+			name: "neighbors should return adjacent values",
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { _, _, ok := r.Neighbors(1); return ok },
+				func(r *robin.Robin[int]) interface{} {
+					r.Add(1)
+					p, n, ok := r.Neighbors(1)
+					return []interface{}{p, n, ok}
+				},
+				func(r *robin.Robin[int]) interface{} { r.Add(2, 3); p, n, _ := r.Neighbors(3); return []int{p, n} },
+				func(r *robin.Robin[int]) interface{} { p, n, _ := r.Neighbors(2); return []int{p, n} },
+				func(r *robin.Robin[int]) interface{} {
+					r.ReverseDirection()
+					p, n, _ := r.Neighbors(2)
+					return []int{p, n}
+				},
+			},
+			want: []interface{}{false, []interface{}{1, 1, true}, []int{2, 1}, []int{1, 3}, []int{3, 1}},
+		},
+		{
+			name:    "zero value should be rejected",
+			options: []robin.BoundedOption[int]{robin.WithRejectZero[int]()},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(0, 1, 0, 2); return r.Contains(0) },
+				func(r *robin.Robin[int]) interface{} { return r.TryAdd(0) },
+				func(r *robin.Robin[int]) interface{} { return next(r, 3) },
+			},
+			want: []interface{}{false, robin.ErrZeroValue, []int{1, 2, 1}},
+		},
+		{
+			name:   "buffer utilization without buffer",
+			maxLen: 1,
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2); return r.BufferUtilization() },
+				func(r *robin.Robin[int]) interface{} { return r.BufferCap() },
+			},
+			want: []interface{}{0.0, 0},
+		},
+		{
+			name:    "buffer utilization should be ratio of buffer length to capacity",
+			maxLen:  1,
+			options: []robin.BoundedOption[int]{robin.WithBuffer[int](robin.NewLIFOBuffer[int](4))},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { return r.BufferUtilization() },
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3); return r.BufferUtilization() },
+				func(r *robin.Robin[int]) interface{} { r.Add(4, 5, 6); return r.BufferUtilization() },
+				func(r *robin.Robin[int]) interface{} { return r.BufferCap() },
+			},
+			want: []interface{}{0.0, 0.5, 1.0, 4},
+		},
+		{
+			name:    "add unique should add all or nothing",
+			maxLen:  2,
+			options: []robin.BoundedOption[int]{robin.WithBuffer[int](robin.NewLIFOBuffer[int](2))},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { return r.AddUnique(1, 2, 3) },
+				func(r *robin.Robin[int]) interface{} { return r.AddUnique(4, 3).Error() },
+				func(r *robin.Robin[int]) interface{} { return errors.Is(r.AddUnique(5, 5), robin.ErrDuplicate) },
+				func(r *robin.Robin[int]) interface{} { return []bool{r.BufferContains(4), r.BufferContains(5)} },
+				func(r *robin.Robin[int]) interface{} { return []int{r.Len(), r.BufferLen()} },
+			},
+			want: []interface{}{nil, "robin: duplicate value: 3", true, []bool{false, false}, []int{2, 1}},
+		},
+		{
+			name:    "least and most served should find extreme serve counts",
+			options: []robin.BoundedOption[int]{robin.WithServeCounts[int]()},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { _, ok := r.LeastServed(); return ok },
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3, 4); v, _ := r.LeastServed(); return v },
+				func(r *robin.Robin[int]) interface{} { next(r, 6); v, _ := r.LeastServed(); return v },
+				func(r *robin.Robin[int]) interface{} { v, _ := r.MostServed(); return v },
+				func(r *robin.Robin[int]) interface{} { r.SeekIndex(1); next(r, 2); v, _ := r.MostServed(); return v },
+			},
+			want: []interface{}{false, 1, 3, 1, 2},
+		},
+		{
+			name: "least and most served without serve counts",
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1); _, ok := r.LeastServed(); return ok },
+				func(r *robin.Robin[int]) interface{} { _, ok := r.MostServed(); return ok },
+			},
+			want: []interface{}{false, false},
+		},
+		{
+			name:    "fully empty should require robin and buffer to be empty",
+			maxLen:  1,
+			options: []robin.BoundedOption[int]{robin.WithBuffer[int](robin.NewLIFOBuffer[int](1))},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { return r.IsFullyEmpty() },
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2); return r.IsFullyEmpty() },
+				func(r *robin.Robin[int]) interface{} { r.Detach(1); return []interface{}{r.Len(), r.IsFullyEmpty()} },
+				func(r *robin.Robin[int]) interface{} { r.PopBuffer(); return r.IsFullyEmpty() },
+			},
+			want: []interface{}{true, false, []interface{}{0, false}, true},
+		},
+		{
+			name:    "removing set should leave no value of the set in robin",
+			maxLen:  3,
+			options: []robin.BoundedOption[int]{robin.WithBuffer[int](robin.NewLIFOBuffer[int](2))},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} {
+					r.Add(1, 2, 3, 4, 5)
+					r.RemoveSet(map[int]struct{}{1: {}, 3: {}, 5: {}, 9: {}})
+					return r.Len()
+				},
+				func(r *robin.Robin[int]) interface{} { return []bool{r.Contains(1), r.Contains(3), r.Contains(5)} },
+				func(r *robin.Robin[int]) interface{} { return []bool{r.Contains(2), r.Contains(4)} },
+				func(r *robin.Robin[int]) interface{} { return r.BufferLen() },
+			},
+			want: []interface{}{2, []bool{false, false, false}, []bool{true, true}, 0},
+		},
+		{
+			name: "seeking after value should move to its successor",
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3); return r.SeekAfter(1) },
+				func(r *robin.Robin[int]) interface{} { return next(r, 1) },
+				func(r *robin.Robin[int]) interface{} { r.SeekAfter(3); return next(r, 1) },
+				func(r *robin.Robin[int]) interface{} { return r.SeekAfter(4) },
+				func(r *robin.Robin[int]) interface{} { return next(r, 1) },
+			},
+			want: []interface{}{true, []int{2}, []int{1}, false, []int{2}},
+		},
+		{
+			name: "next until mark should complete the loop",
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Mark(); return r.NextUntilMark() },
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3, 4); r.Next(); r.Mark(); return next(r, 2) },
+				func(r *robin.Robin[int]) interface{} { return r.NextUntilMark() },
+				func(r *robin.Robin[int]) interface{} { return r.NextUntilMark() },
+				func(r *robin.Robin[int]) interface{} { r.Remove(4); return r.NextUntilMark() },
+				func(r *robin.Robin[int]) interface{} { r.Remove(2); return r.NextUntilMark() },
+			},
+			want: []interface{}{[]int(nil), []int{2, 3}, []int{4, 1}, []int{2, 3, 4, 1}, []int{2, 3, 1}, []int(nil)},
+		},
+		{
+			name:    "next until mark should drop the mark when its value is replaced from the buffer",
+			maxLen:  2,
+			options: []robin.BoundedOption[int]{robin.WithBuffer[int](robin.NewLIFOBuffer[int](1))},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} {
+					r.Add(1, 2, 3)
+					r.Next()
+					r.Mark()
+					r.Remove(2)
+					return r.Contains(3)
+				},
+				func(r *robin.Robin[int]) interface{} { return r.NextUntilMark() },
+				func(r *robin.Robin[int]) interface{} { return next(r, 2) },
+			},
+			want: []interface{}{true, []int(nil), []int{3, 1}},
+		},
+		{
+			name:    "adding with stable cursor should keep next value",
+			options: []robin.BoundedOption[int]{robin.WithStableCursor[int]()},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3); r.Next(); r.Add(4, 5); return next(r, 5) },
+				func(r *robin.Robin[int]) interface{} { r.AddBefore(2, 6); return next(r, 1) },
+				func(r *robin.Robin[int]) interface{} { r.Reset(); r.Add(7); return next(r, 1) },
+			},
+			want: []interface{}{[]int{2, 3, 1, 4, 5}, []int{2}, []int{7}},
+		},
+		{
+			name:    "unbound should drain buffer into robin",
+			maxLen:  2,
+			options: []robin.BoundedOption[int]{robin.WithBuffer[int](robin.NewLIFOBuffer[int](2))},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3, 4); return r.IsFull() },
+				func(r *robin.Robin[int]) interface{} { r.Unbound(); return []int{r.Len(), r.BufferLen()} },
+				func(r *robin.Robin[int]) interface{} { return next(r, 4) },
+				func(r *robin.Robin[int]) interface{} { r.Add(5, 6); return []interface{}{r.IsFull(), r.Len()} },
+			},
+			want: []interface{}{true, []int{4, 0}, []int{4, 3, 1, 2}, []interface{}{false, 6}},
+		},
+		{
+			name:    "range should return sorted values between bounds",
+			maxLen:  5,
+			options: []robin.BoundedOption[int]{robin.WithOrderedIndex[int](), robin.WithBuffer[int](robin.NewLIFOBuffer[int](1))},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(7, 3, 9, 1, 5, 4); return r.Range(2, 8) },
+				func(r *robin.Robin[int]) interface{} { r.Remove(5, 9); return r.Range(0, 10) },
+				func(r *robin.Robin[int]) interface{} { return r.Range(8, 2) },
+				func(r *robin.Robin[int]) interface{} { r.Reset(); r.Add(2); return r.Range(2, 2) },
+			},
+			want: []interface{}{[]int{3, 5, 7}, []int{1, 3, 4, 7}, []int{}, []int{2}},
+		},
+		{
+			name: "range without ordered index",
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { r.Add(1); return r.Range(0, 2) },
+			},
+			want: []interface{}{[]int(nil)},
+		},
+		{
+			name:    "starved should flag values served far below mean",
+			options: []robin.BoundedOption[int]{robin.WithServeCounts[int]()},
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { return r.Starved(0.5) },
+				func(r *robin.Robin[int]) interface{} { r.Add(1, 2, 3, 4); next(r, 8); return r.Starved(0.1) },
+				func(r *robin.Robin[int]) interface{} {
+					for i := 0; i < 6; i++ {
+						r.SeekIndex(0)
+						r.Next()
+					}
+					r.SeekIndex(1)
+					r.Next()
+					return r.Starved(0.4)
+				},
+				func(r *robin.Robin[int]) interface{} { return r.Starved(0.1) },
+			},
+			want: []interface{}{[]int(nil), []int(nil), []int{3, 4}, []int{2, 3, 4}},
+		},
+		{
+			name: "next into should fill slice and advance",
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { dst := make([]int, 3); return r.NextInto(dst) },
+				func(r *robin.Robin[int]) interface{} {
+					r.Add(1, 2, 3, 4, 5)
+					dst := make([]int, 3)
+					return []interface{}{r.NextInto(dst), dst}
+				},
+				func(r *robin.Robin[int]) interface{} { dst := make([]int, 3); r.NextInto(dst); return dst },
+				func(r *robin.Robin[int]) interface{} { return next(r, 1) },
+			},
+			want: []interface{}{0, []interface{}{3, []int{1, 2, 3}}, []int{4, 5, 1}, []int{2}},
+		},
+		{
+			name: "peek into should fill slice without advancing",
+			operations: []func(*robin.Robin[int]) interface{}{
+				func(r *robin.Robin[int]) interface{} { dst := make([]int, 3); return r.PeekInto(dst) },
+				func(r *robin.Robin[int]) interface{} {
+					r.Add(1, 2, 3)
+					r.Next()
+					dst := make([]int, 5)
+					return []interface{}{r.PeekInto(dst), dst}
+				},
+				func(r *robin.Robin[int]) interface{} { return next(r, 5) },
+			},
+			want: []interface{}{0, []interface{}{5, []int{2, 3, 1, 2, 3}}, []int{2, 3, 1, 2, 3}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var r *robin.Robin[int]
+			if tc.maxLen > 0 {
+				r = robin.NewBounded[int](tc.maxLen, tc.options...)
+			} else {
+				r = robin.NewUnbounded[int](tc.options...)
+			}
+			var got []interface{}
+			for _, op := range tc.operations {
+				got = append(got, op(r))
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Test %q failed: got %v, want %v", tc.name, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFingerprintString(t *testing.T) {
+	a := robin.NewUnbounded[string]()
+	a.Add("a", "b", "c")
+	b := robin.NewUnbounded[string]()
+	b.Add("c", "b", "a")
+	c := robin.NewUnbounded[string]()
+	c.Add("a", "b", "d")
+
+	if a.Fingerprint() != b.Fingerprint() {
+		t.Errorf("Fingerprints of robins with equal values differ")
+	}
+	if a.Fingerprint() == c.Fingerprint() {
+		t.Errorf("Fingerprints of robins with different values are equal")
+	}
+}
+
+func TestToDOT(t *testing.T) {
+	r := robin.NewUnbounded[int]()
+	if got, want := r.ToDOT(), "digraph robin {\n}\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	r.Add(1, 2, 3)
+	r.Next()
+	dot := r.ToDOT()
+	for _, want := range []string{
+		"digraph robin {",
+		`n0 [label="2", style=filled, fillcolor=lightblue];`,
+		`n1 [label="3"];`,
+		`n2 [label="1"];`,
+		`n0 -> n1 [label="next"];`,
+		`n2 -> n0 [label="next"];`,
+		`n1 -> n0 [label="prev", style=dashed];`,
+		`n0 -> n2 [label="prev", style=dashed];`,
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("DOT output %q does not contain %q", dot, want)
+		}
+	}
+}
+
+func TestSeekRandom(t *testing.T) {
+	r := robin.NewUnbounded[int]()
+	if _, ok := r.SeekRandom(rand.New(rand.NewSource(1))); ok {
+		t.Errorf("SeekRandom on empty robin returned true")
+	}
+
+	r.Add(0, 1, 2, 3, 4)
+	want := rand.New(rand.NewSource(1)).Intn(5)
+	got, ok := r.SeekRandom(rand.New(rand.NewSource(1)))
+	if !ok || got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if v, _ := r.Next(); v != want {
+		t.Errorf("Next after SeekRandom returned %v, want %v", v, want)
+	}
+}
+
+func TestOverflowRobin(t *testing.T) {
+	overflow := robin.NewBounded[int](2)
+	r := robin.NewBounded(2, robin.WithOverflowRobin(overflow))
+
+	r.Add(1, 2, 3, 4, 5)
+	if got, want := next(overflow, 3), []int{4, 3, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("overflow robin got %v, want %v", got, want)
+	}
+	if got, want := r.BufferLen(), 2; got != want {
+		t.Errorf("BufferLen got %v, want %v", got, want)
+	}
+
+	r.Remove(1)
+	if !r.Contains(3) || overflow.Contains(3) {
+		t.Errorf("removed value was not replaced by the next overflow value")
+	}
+	if got, want := next(r, 2), []int{3, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("robin got %v, want %v", got, want)
+	}
+	if got, want := overflow.Len(), 1; got != want {
+		t.Errorf("overflow Len got %v, want %v", got, want)
+	}
+}
+
+func TestNextHook(t *testing.T) {
+	var got []int
+	r := robin.NewUnbounded(robin.WithNextHook(func(v int) { got = append(got, v) }))
+
+	r.Next()
+	if len(got) != 0 {
+		t.Errorf("hook fired on empty robin with %v", got)
+	}
+
+	r.Add(1, 2)
+	next(r, 3)
+	if want := []int{1, 2, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestNextHookRemove(t *testing.T) {
+	var r *robin.Robin[int]
+	r = robin.NewUnbounded(robin.WithNextHook(func(v int) {
+		if v%2 == 0 {
+			r.Remove(v)
+		}
+		if v == 3 {
+			r.Remove(5)
+		}
+	}))
+	r.Add(1, 2, 3, 4, 5, 6)
+
+	if got, want := next(r, 6), []int{1, 2, 3, 4, 6, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got, want := r.Len(), 2; got != want {
+		t.Errorf("Len got %v, want %v", got, want)
+	}
+	if !r.IsConsistent() {
+		t.Errorf("robin is not consistent")
+	}
+}
+
+func TestFromSet(t *testing.T) {
+	set := map[int]struct{}{5: {}, 3: {}, 9: {}, 1: {}, 7: {}}
+	r := robin.FromSet(set)
+	if got, want := next(r, 6), []int{1, 3, 5, 7, 9, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	s := robin.FromSet(map[string]struct{}{"b": {}, "c": {}, "a": {}})
+	var got []string
+	for i := 0; i < 3; i++ {
+		v, _ := s.Next()
+		got = append(got, v)
+	}
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestReplacementHook(t *testing.T) {
+	var got [][2]int
+	r := robin.NewBounded(2,
+		robin.WithBuffer[int](robin.NewLIFOBuffer[int](2)),
+		robin.WithReplacementHook(func(removed, replacement int) {
+			got = append(got, [2]int{removed, replacement})
+		}),
+	)
+
+	r.Add(1, 2, 3, 4)
+	r.Remove(1, 2, 3)
+	if want := [][2]int{{1, 4}, {2, 3}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestBufferOverflowHook(t *testing.T) {
+	var got []int
+	r := robin.NewBounded(2,
+		robin.WithBuffer[int](robin.NewLIFOBuffer[int](2)),
+		robin.WithBufferOverflowHook(func(v int) { got = append(got, v) }),
+	)
+
+	r.Add(1, 2, 3, 4)
+	if len(got) != 0 {
+		t.Errorf("hook called before buffer was full: %v", got)
+	}
+	r.Add(5, 6)
+	if want := []int{3, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestDuplicateHook(t *testing.T) {
+	var got []int
+	r := robin.NewBounded(3,
+		robin.WithBuffer[int](robin.NewLIFOBuffer[int](2)),
+		robin.WithDuplicateHook(func(v int) { got = append(got, v) }),
+	)
+
+	r.Add(1, 2, 3, 4)
+	if len(got) != 0 {
+		t.Errorf("hook called for new values: %v", got)
+	}
+	r.Add(2, 5, 4, 2, 1)
+	if want := []int{2, 4, 2, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got, want := r.Len(), 3; got != want {
+		t.Errorf("Len got %v, want %v", got, want)
+	}
+}
+
+func TestDuplicateHookFull(t *testing.T) {
+	var got []int
+	r := robin.NewBounded(2,
+		robin.WithDuplicateHook(func(v int) { got = append(got, v) }),
+	)
+
+	r.Add(1, 1, 2)
+	r.Add(2, 3)
+	r.Add(3, 1, 2)
+	if want := []int{1, 2, 1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestBufferOverflowHookLRU(t *testing.T) {
+	var got []int
+	r := robin.NewBounded(1,
+		robin.WithBuffer[int](robin.NewLRUBuffer[int](1)),
+		robin.WithBufferOverflowHook(func(v int) { got = append(got, v) }),
+	)
+
+	r.Add(1, 2)
+	if v, ok := r.NextEviction(); !ok || v != 2 {
+		t.Errorf("NextEviction got %v, %v, want 2, true", v, ok)
+	}
+	r.Add(3)
+	if want := []int{2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestUnboundKeepsBufferedValues(t *testing.T) {
+	strict := false
+	r := robin.NewBounded(1,
+		robin.WithBuffer[int](robin.NewLIFOBuffer[int](3)),
+		robin.WithTotalCap[int](4),
+		robin.WithValidator(func(v int) error {
+			if strict {
+				return errors.New("strict")
+			}
+			return nil
+		}),
+	)
+	r.Add(1, 2, 3, 4)
+	strict = true
+
+	r.Unbound()
+	if r.Len() != 4 || r.BufferLen() != 0 {
+		t.Errorf("got Len %v and BufferLen %v, want 4 and 0", r.Len(), r.BufferLen())
+	}
+	if got, want := next(r, 4), []int{4, 3, 2, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestIsConsistent(t *testing.T) {
+	r := robin.NewBounded(3, robin.WithBuffer[int](robin.NewLIFOBuffer[int](2)))
+	if !r.IsConsistent() {
+		t.Errorf("empty robin is not consistent")
+	}
+
+	r.Add(1, 2, 3, 4, 5)
+	r.Next()
+	r.Remove(2)
+	r.ReverseDirection()
+	r.Add(6)
+	if !r.IsConsistent() {
+		t.Errorf("healthy robin is not consistent")
+	}
+
+	r.BreakLink()
+	if r.IsConsistent() {
+		t.Errorf("corrupted robin is consistent")
+	}
+}
+
+func TestFromSliceWithBuffer(t *testing.T) {
+	b := robin.NewLIFOBuffer[int](2)
+	r := robin.FromSliceWithBuffer[int]([]int{1, 2, 2, 3, 1, 4, 5, 6}, 3, b)
+
+	if got, want := next(r, 3), []int{1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got robin values %v, want %v", got, want)
+	}
+	var got []int
+	for b.Len() > 0 {
+		v, _ := b.Pop()
+		got = append(got, v)
+	}
+	if want := []int{6, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got buffer values %v, want %v", got, want)
+	}
+}
+
+func TestBloomIndex(t *testing.T) {
+	r := robin.NewUnbounded(robin.WithBloomIndex[int](4096, 3))
+	for i := 0; i < 1000; i++ {
+		r.Add(i)
+	}
+	for i := 0; i < 1000; i += 2 {
+		r.Remove(i)
+	}
+
+	var falsePositives int
+	for i := 0; i < 2000; i++ {
+		might, contains := r.MightContain(i), r.Contains(i)
+		if contains && !might {
+			t.Fatalf("MightContain(%d) = false for value in robin", i)
+		}
+		if might && !contains {
+			falsePositives++
+		}
+	}
+	if falsePositives > 300 {
+		t.Errorf("got %d false positives out of 1500 values not in robin", falsePositives)
+	}
+
+	r.Reset()
+	if r.MightContain(1) {
+		t.Errorf("MightContain(1) = true after reset")
+	}
+}
+
+func TestValidator(t *testing.T) {
+	errEmpty := errors.New("empty string")
+	r := robin.NewUnbounded(robin.WithValidator(func(s string) error {
+		if s == "" {
+			return errEmpty
+		}
+		return nil
+	}))
+
+	r.Add("a", "", "b")
+	if r.Len() != 2 || r.Contains("") {
+		t.Errorf("Add should skip invalid values, got len %d", r.Len())
+	}
+	if err := r.AddValidated("c", "", "d"); !errors.Is(err, errEmpty) {
+		t.Errorf("AddValidated got %v, want %v", err, errEmpty)
+	}
+	if r.Contains("c") || r.Contains("d") {
+		t.Errorf("AddValidated should add nothing if a value is invalid")
+	}
+	if err := r.AddValidated("c", "d"); err != nil {
+		t.Errorf("AddValidated got %v, want nil", err)
+	}
+	if err := r.TryAdd(""); !errors.Is(err, errEmpty) {
+		t.Errorf("TryAdd got %v, want %v", err, errEmpty)
+	}
+	if r.AddAfter("a", "") {
+		t.Errorf("AddAfter of invalid value returned true")
+	}
+	if r.Len() != 4 {
+		t.Errorf("Len got %d, want 4", r.Len())
+	}
+}
+
+func TestRejectZeroString(t *testing.T) {
+	r := robin.NewUnbounded(robin.WithRejectZero[string]())
+	r.Add("a", "", "b")
+	if r.Contains("") || r.Len() != 2 {
+		t.Errorf("empty string should be rejected, got len %d", r.Len())
+	}
+	if err := r.AddValidated("c", ""); !errors.Is(err, robin.ErrZeroValue) {
+		t.Errorf("AddValidated got %v, want %v", err, robin.ErrZeroValue)
+	}
+}
+
+func TestCompact(t *testing.T) {
+	r := robin.NewUnbounded[int]()
+	for i := 0; i < 1000; i++ {
+		r.Add(i)
+	}
+	for i := 0; i < 995; i++ {
+		r.Remove(i)
+	}
+	r.Next()
+
+	id := r.NodesID()
+	r.Compact()
+	// the map is rebuilt rather than kept with its old capacity
+	if r.NodesID() == id {
+		t.Errorf("Compact did not rebuild the map")
+	}
+	if got, want := r.Len(), 5; got != want {
+		t.Errorf("Len got %v, want %v", got, want)
+	}
+	if !r.ContainsAll(995, 996, 997, 998, 999) || r.Contains(0) {
+		t.Errorf("Compact changed the values")
+	}
+	if got, want := next(r, 5), []int{998, 997, 996, 995, 999}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestIdleTimeout(t *testing.T) {
+	now := time.Unix(0, 0)
+	r := robin.NewBounded(3,
+		robin.WithBuffer[int](robin.NewLIFOBuffer[int](1)),
+		robin.WithIdleTimeout[int](time.Minute),
+		robin.WithClock[int](func() time.Time { return now }),
+	)
+	r.Add(1, 2, 3)
+
+	now = now.Add(40 * time.Second)
+	next(r, 2)
+	now = now.Add(40 * time.Second)
+	if got, want := next(r, 3), []int{1, 2, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if r.Contains(3) {
+		t.Errorf("idle value was not removed")
+	}
+
+	r.Add(4, 5)
+	now = now.Add(61 * time.Second)
+	r.Remove()
+	if got, want := r.Len(), 1; got != want {
+		t.Errorf("Len got %v, want %v", got, want)
+	}
+	if !r.Contains(5) {
+		t.Errorf("idle value should be replaced from the buffer")
+	}
+
+	now = now.Add(61 * time.Second)
+	if err := r.TryRemove(5); !errors.Is(err, robin.ErrNotFound) {
+		t.Errorf("TryRemove got %v, want %v", err, robin.ErrNotFound)
+	}
+	if got, want := r.Len(), 0; got != want {
+		t.Errorf("Len got %v, want %v", got, want)
+	}
+}
+
+func TestChaining(t *testing.T) {
+	r := robin.NewUnbounded[int]()
+	if got := r.With(1, 2, 3); got != r {
+		t.Errorf("With returned %p, want receiver %p", got, r)
+	}
+	if got := r.With(4).Without(2, 5); got != r {
+		t.Errorf("Without returned %p, want receiver %p", got, r)
+	}
+	if got, want := next(r, 3), []int{4, 1, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got, want := robin.NewUnbounded[int]().With(1, 2).Len(), 2; got != want {
+		t.Errorf("Len got %v, want %v", got, want)
+	}
+}
+
+func TestSetBuffer(t *testing.T) {
+	if robin.NewUnbounded[int]().SetBuffer(robin.NewSliceBuffer[int]()) != nil {
+		t.Errorf("SetBuffer on unbounded robin returned old buffer")
+	}
+
+	lifo := robin.NewLIFOBuffer[int](3)
+	r := robin.NewBounded(2, robin.WithBuffer[int](lifo))
+	r.Add(1, 2, 3, 4, 5)
+
+	if old := r.SetBuffer(robin.NewSliceBuffer[int]()); old != robin.Buffer[int](lifo) || lifo.Len() != 0 {
+		t.Fatalf("SetBuffer should return old buffer emptied by migration")
+	}
+	if got, want := r.BufferLen(), 3; got != want {
+		t.Errorf("BufferLen got %v, want %v", got, want)
+	}
+	r.Remove(1)
+	if !r.Contains(5) {
+		t.Errorf("Remove should pop most recent value from new buffer")
+	}
+	r.Remove(2)
+	if !r.Contains(4) {
+		t.Errorf("Remove should pop values in migrated order")
+	}
+
+	small := robin.NewLIFOBuffer[int](0)
+	old := r.SetBuffer(small)
+	if old.Len() != 1 || !old.Contains(3) || r.BufferLen() != 0 {
+		t.Errorf("SetBuffer should not migrate values to a buffer without room")
+	}
+}
+
+func TestServeHistory(t *testing.T) {
+	now := time.Unix(0, 0)
+	r := robin.NewUnbounded(
+		robin.WithServeHistory[int](3),
+		robin.WithClock[int](func() time.Time { return now }),
+	)
+	r.Add(1, 2, 3)
+	for i := 0; i < 4; i++ {
+		now = now.Add(time.Second)
+		r.Next()
+	}
+
+	want := []robin.ServeEvent[int]{
+		{Value: 2, Time: time.Unix(2, 0)},
+		{Value: 3, Time: time.Unix(3, 0)},
+		{Value: 1, Time: time.Unix(4, 0)},
+	}
+	if got := r.ServeHistory(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ServeHistory got %v, want %v", got, want)
+	}
+	if got, ok := r.LastServed(1); !ok || !got.Equal(time.Unix(4, 0)) {
+		t.Errorf("LastServed(1) got %v, %v, want %v", got, ok, time.Unix(4, 0))
+	}
+	if got, ok := r.LastServed(2); !ok || !got.Equal(time.Unix(2, 0)) {
+		t.Errorf("LastServed(2) got %v, %v, want %v", got, ok, time.Unix(2, 0))
+	}
+	r.Add(4)
+	if _, ok := r.LastServed(4); ok {
+		t.Errorf("LastServed of value never served returned true")
+	}
+	if _, ok := r.LastServed(5); ok {
+		t.Errorf("LastServed of absent value returned true")
+	}
+}
+
+func TestSameTier(t *testing.T) {
+	overflow := robin.NewBounded[int](3)
+	r := robin.NewBounded(2, robin.WithOverflowRobin(overflow))
+	r.Add(1, 2, 3, 4)
+
+	tests := []struct {
+		a, b     int
+		same, ok bool
+	}{
+		{1, 2, true, true},
+		{3, 4, true, true},
+		{1, 3, false, true},
+		{4, 2, false, true},
+		{1, 5, false, false},
+		{5, 5, false, false},
+	}
+	for _, tc := range tests {
+		if same, ok := r.SameTier(tc.a, tc.b); same != tc.same || ok != tc.ok {
+			t.Errorf("SameTier(%d, %d) got %v, %v, want %v, %v", tc.a, tc.b, same, ok, tc.same, tc.ok)
+		}
+	}
+}
+
+func TestSampleDistributionWeighted(t *testing.T) {
+	r := robin.NewUnbounded(robin.WithWFQ[int]())
+	r.Add(1, 2, 3)
+	weights := map[int]float64{1: 1, 2: 2, 3: 5}
+	for v, w := range weights {
+		r.SetWeight(v, w)
+	}
+
+	const n = 800
+	counts := r.SampleDistribution(n)
+	for v, w := range weights {
+		want := n * w / 8
+		if got := float64(counts[v]); got < want*0.95 || got > want*1.05 {
+			t.Errorf("value %d got %v samples, want %v within 5%%", v, got, want)
+		}
 	}
 }
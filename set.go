@@ -0,0 +1,88 @@
+package robin
+
+// Set is an insertion-ordered set backed by a [Robin], for when only
+// the bounded set and buffer behavior is needed and not the rotation.
+// Values added to a full set are pushed to the buffer, if any, and
+// removed values are replaced from it, like for a robin.
+type Set[T comparable] struct {
+	r *Robin[T]
+}
+
+// SetOption configures a [Set]. Only the options that keep the set
+// semantics are available, so options of a [Robin] that remove values
+// as they are returned, such as [WithMaxServes] and [WithIdleTimeout],
+// cannot be used with a set.
+type SetOption[T comparable] func(*Robin[T])
+
+// WithSetBuffer sets the buffer for a bounded [Set], with the same
+// semantics as [WithBuffer] for a bounded [Robin].
+func WithSetBuffer[T comparable](buffer Buffer[T]) SetOption[T] {
+	return SetOption[T](WithBuffer(buffer))
+}
+
+// WithSetEvictOldest makes a full [Set] evict its oldest value to make
+// room for an added value, like [WithEvictOldest] for a [Robin].
+func WithSetEvictOldest[T comparable]() SetOption[T] {
+	return SetOption[T](WithEvictOldest[T]())
+}
+
+// WithSetValidator sets a function that rejects values added to a
+// [Set], like [WithValidator] for a [Robin].
+func WithSetValidator[T comparable](fn func(T) error) SetOption[T] {
+	return SetOption[T](WithValidator(fn))
+}
+
+// NewSet creates a new [Set] with a maximum length, see [NewBounded].
+// If the length is negative or zero, the set is unbounded and any
+// buffer is ignored.
+func NewSet[T comparable](len int, options ...SetOption[T]) *Set[T] {
+	robinOptions := make([]BoundedOption[T], 0, cap(options))
+	for _, option := range options {
+		robinOptions = append(robinOptions, BoundedOption[T](option))
+	}
+	return &Set[T]{r: NewBounded(len, robinOptions...)}
+}
+
+// Add values to the set. See [Robin.Add].
+func (s *Set[T]) Add(vs ...T) {
+	s.r.Add(vs...)
+}
+
+// Remove values from the set. See [Robin.Remove].
+func (s *Set[T]) Remove(vs ...T) {
+	s.r.Remove(vs...)
+}
+
+// Contains returns true if the value is in the set. Buffered values
+// are not in the set, see [Set.BufferContains].
+func (s *Set[T]) Contains(v T) bool {
+	return s.r.Contains(v)
+}
+
+// BufferContains returns true if the value is in the buffer.
+func (s *Set[T]) BufferContains(v T) bool {
+	return s.r.BufferContains(v)
+}
+
+// Len returns the number of values in the set.
+func (s *Set[T]) Len() int {
+	return s.r.Len()
+}
+
+// Reset the set. If there is a buffer, it is reset as well.
+func (s *Set[T]) Reset() {
+	s.r.Reset()
+}
+
+// All returns an iterator over the values of the set in insertion
+// order, where a value replaced from the buffer counts as newly
+// inserted. The set must not be modified during iteration.
+func (s *Set[T]) All() func(yield func(T) bool) {
+	return func(yield func(T) bool) {
+		for node := s.r.oldest; node != nil; node = node.newer {
+			if !yield(node.v) {
+				return
+			}
+		}
+	}
+}
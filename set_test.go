@@ -0,0 +1,58 @@
+package robin_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/embeage/robin"
+)
+
+// values returns the values of the set in insertion order
+func values(s *robin.Set[int]) []int {
+	var vs []int
+	s.All()(func(v int) bool {
+		vs = append(vs, v)
+		return true
+	})
+	return vs
+}
+
+func TestSet(t *testing.T) {
+	s := robin.NewSet(3, robin.WithSetBuffer[int](robin.NewLIFOBuffer[int](2)))
+	s.Add(3, 1, 3, 2, 1)
+	if got, want := values(s), []int{3, 1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	s.Add(4, 5)
+	if s.Len() != 3 || s.Contains(4) || !s.BufferContains(4) {
+		t.Errorf("values added to full set should be buffered")
+	}
+
+	s.Remove(1)
+	if got, want := values(s), []int{3, 2, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	s.Reset()
+	if s.Len() != 0 || s.BufferContains(4) {
+		t.Errorf("Reset should clear set and buffer")
+	}
+}
+
+func TestSetOptions(t *testing.T) {
+	s := robin.NewSet(2,
+		robin.WithSetEvictOldest[int](),
+		robin.WithSetValidator(func(v int) error {
+			if v < 0 {
+				return errors.New("negative")
+			}
+			return nil
+		}),
+	)
+	s.Add(1, -2, 2, 3)
+	if got, want := values(s), []int{2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
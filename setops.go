@@ -0,0 +1,47 @@
+package robin
+
+// Intersect returns a new unbounded [Robin] with the values that are
+// in both robins, in the rotation order of a starting from its current
+// position. Neither robin is modified.
+func Intersect[T comparable](a, b *Robin[T]) *Robin[T] {
+	var vs []T
+	for _, v := range a.values() {
+		if b.Contains(v) {
+			vs = append(vs, v)
+		}
+	}
+	r := NewUnbounded[T]()
+	r.Add(vs...)
+	return r
+}
+
+// Difference returns a new unbounded [Robin] with the values of a that
+// are not in b, in the rotation order of a starting from its current
+// position. Neither robin is modified.
+func Difference[T comparable](a, b *Robin[T]) *Robin[T] {
+	var vs []T
+	for _, v := range a.values() {
+		if !b.Contains(v) {
+			vs = append(vs, v)
+		}
+	}
+	r := NewUnbounded[T]()
+	r.Add(vs...)
+	return r
+}
+
+// Union returns a new unbounded [Robin] with the values of a in its
+// rotation order, followed by the values of b that are not in a in the
+// rotation order of b, each starting from the current position.
+// Neither robin is modified.
+func Union[T comparable](a, b *Robin[T]) *Robin[T] {
+	vs := a.values()
+	for _, v := range b.values() {
+		if !a.Contains(v) {
+			vs = append(vs, v)
+		}
+	}
+	r := NewUnbounded[T]()
+	r.Add(vs...)
+	return r
+}
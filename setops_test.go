@@ -0,0 +1,65 @@
+package robin_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/embeage/robin"
+)
+
+// newRobin returns an unbounded robin with the values added and the
+// current position advanced by skip
+func newRobin(skip int, vs ...int) *robin.Robin[int] {
+	r := robin.NewUnbounded[int]()
+	r.Add(vs...)
+	next(r, skip)
+	return r
+}
+
+func TestIntersect(t *testing.T) {
+	a := newRobin(1, 1, 2, 3, 4, 5)
+	b := newRobin(0, 6, 4, 2, 1)
+
+	if got, want := next(robin.Intersect(a, b), 3), []int{2, 4, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if a.Len() != 5 || b.Len() != 4 {
+		t.Errorf("Intersect should not modify the robins")
+	}
+	if got := robin.Intersect(a, newRobin(0, 7, 8)).Len(); got != 0 {
+		t.Errorf("Intersect of disjoint robins got Len %v, want 0", got)
+	}
+}
+
+func TestDifference(t *testing.T) {
+	a := newRobin(1, 1, 2, 3, 4, 5)
+
+	if got, want := next(robin.Difference(a, newRobin(0, 6, 4, 2)), 3), []int{3, 5, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("partial overlap got %v, want %v", got, want)
+	}
+	if got := robin.Difference(a, newRobin(0, 5, 4, 3, 2, 1, 0)).Len(); got != 0 {
+		t.Errorf("full overlap got Len %v, want 0", got)
+	}
+	if got, want := next(robin.Difference(a, newRobin(0, 7)), 5), next(a, 5); !reflect.DeepEqual(got, want) {
+		t.Errorf("disjoint got %v, want %v", got, want)
+	}
+}
+
+func TestUnion(t *testing.T) {
+	a := newRobin(1, 1, 2, 3)
+	b := newRobin(1, 4, 3, 5, 1)
+
+	u := robin.Union(a, b)
+	if got, want := u.Len(), 5; got != want {
+		t.Errorf("Len got %v, want %v", got, want)
+	}
+	if got, want := next(u, 5), []int{2, 3, 1, 5, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got, want := next(robin.Union(a, newRobin(0, 3, 1)), 3), next(a, 3); !reflect.DeepEqual(got, want) {
+		t.Errorf("full overlap got %v, want %v", got, want)
+	}
+	if a.Len() != 3 || b.Len() != 4 {
+		t.Errorf("Union should not modify the robins")
+	}
+}
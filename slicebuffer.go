@@ -0,0 +1,89 @@
+package robin
+
+// SliceBuffer is a buffer backed by a growable slice that keeps its
+// values in insertion order. Popping returns the most recent value,
+// and [SliceBuffer.PopIndex] pops a value at any position.
+//
+// Unlike [LIFOBuffer], it has no fixed capacity and never overwrites
+// values, so it can grow without bound. Push, Pop and Contains are
+// O(1), while PopIndex and Remove are O(n).
+type SliceBuffer[T comparable] struct {
+	vs    []T
+	count map[T]int
+}
+
+// NewSliceBuffer creates a new empty [SliceBuffer].
+func NewSliceBuffer[T comparable]() *SliceBuffer[T] {
+	return &SliceBuffer[T]{count: make(map[T]int)}
+}
+
+// Push a value to the buffer as the most recent value.
+func (b *SliceBuffer[T]) Push(v T) {
+	b.vs = append(b.vs, v)
+	b.count[v]++
+}
+
+// values returns the values in the order they would be popped
+func (b *SliceBuffer[T]) values() []T {
+	vs := make([]T, 0, len(b.vs))
+	for i := len(b.vs) - 1; i >= 0; i-- {
+		vs = append(vs, b.vs[i])
+	}
+	return vs
+}
+
+// Pop the most recent value from the buffer. If the buffer is empty,
+// the second return value is false.
+func (b *SliceBuffer[T]) Pop() (T, bool) {
+	return b.PopIndex(len(b.vs) - 1)
+}
+
+// PopIndex pops the value at index i in insertion order, where index 0
+// is the oldest value, keeping the order of the other values. If i is
+// out of range, the second return value is false.
+func (b *SliceBuffer[T]) PopIndex(i int) (T, bool) {
+	if i < 0 || i >= len(b.vs) {
+		return *new(T), false
+	}
+	v := b.vs[i]
+	copy(b.vs[i:], b.vs[i+1:])
+	b.vs[len(b.vs)-1] = *new(T)
+	b.vs = b.vs[:len(b.vs)-1]
+	b.count[v]--
+	if b.count[v] == 0 {
+		delete(b.count, v)
+	}
+	return v, true
+}
+
+// Remove the most recent occurrence of a value from the buffer,
+// keeping the order of the other values. It returns false if the
+// value is not in the buffer.
+func (b *SliceBuffer[T]) Remove(v T) bool {
+	if !b.Contains(v) {
+		return false
+	}
+	for i := len(b.vs) - 1; ; i-- {
+		if b.vs[i] == v {
+			b.PopIndex(i)
+			return true
+		}
+	}
+}
+
+// Contains returns true if the value is in the buffer.
+func (b *SliceBuffer[T]) Contains(v T) bool {
+	_, ok := b.count[v]
+	return ok
+}
+
+// Len returns the number of values in the buffer.
+func (b *SliceBuffer[T]) Len() int {
+	return len(b.vs)
+}
+
+// Reset the buffer.
+func (b *SliceBuffer[T]) Reset() {
+	b.vs = nil
+	b.count = make(map[T]int)
+}
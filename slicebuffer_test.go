@@ -0,0 +1,89 @@
+package robin_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/embeage/robin"
+)
+
+func TestSliceBuffer(t *testing.T) {
+	tests := []struct {
+		name       string
+		operations []func(*robin.SliceBuffer[int]) interface{}
+		want       []interface{}
+	}{
+		{
+			name: "basic push and pop",
+			operations: []func(*robin.SliceBuffer[int]) interface{}{
+				func(b *robin.SliceBuffer[int]) interface{} { b.Push(1); b.Push(2); v, _ := b.Pop(); return v },
+				func(b *robin.SliceBuffer[int]) interface{} { v, _ := b.Pop(); return v },
+				func(b *robin.SliceBuffer[int]) interface{} { _, ok := b.Pop(); return ok },
+			},
+			want: []interface{}{2, 1, false},
+		},
+		{
+			name: "pushing should grow instead of overwriting",
+			operations: []func(*robin.SliceBuffer[int]) interface{}{
+				func(b *robin.SliceBuffer[int]) interface{} {
+					for i := 0; i < 100; i++ {
+						b.Push(i)
+					}
+					return b.Len()
+				},
+				func(b *robin.SliceBuffer[int]) interface{} { return b.Contains(0) },
+			},
+			want: []interface{}{100, true},
+		},
+		{
+			name: "pop index should keep order",
+			operations: []func(*robin.SliceBuffer[int]) interface{}{
+				func(b *robin.SliceBuffer[int]) interface{} {
+					b.Push(1)
+					b.Push(2)
+					b.Push(3)
+					v, _ := b.PopIndex(1)
+					return v
+				},
+				func(b *robin.SliceBuffer[int]) interface{} { return b.Contains(2) },
+				func(b *robin.SliceBuffer[int]) interface{} { _, ok := b.PopIndex(2); return ok },
+				func(b *robin.SliceBuffer[int]) interface{} { v, _ := b.PopIndex(0); return v },
+				func(b *robin.SliceBuffer[int]) interface{} { v, _ := b.Pop(); return v },
+			},
+			want: []interface{}{2, false, false, 1, 3},
+		},
+		{
+			name: "basic remove",
+			operations: []func(*robin.SliceBuffer[int]) interface{}{
+				func(b *robin.SliceBuffer[int]) interface{} { b.Push(1); b.Push(2); b.Push(3); return b.Remove(2) },
+				func(b *robin.SliceBuffer[int]) interface{} { return b.Remove(2) },
+				func(b *robin.SliceBuffer[int]) interface{} { v, _ := b.Pop(); return v },
+				func(b *robin.SliceBuffer[int]) interface{} { v, _ := b.Pop(); return v },
+			},
+			want: []interface{}{true, false, 3, 1},
+		},
+		{
+			name: "basic reset",
+			operations: []func(*robin.SliceBuffer[int]) interface{}{
+				func(b *robin.SliceBuffer[int]) interface{} { b.Push(1); b.Reset(); return b.Len() },
+				func(b *robin.SliceBuffer[int]) interface{} { return b.Contains(1) },
+				func(b *robin.SliceBuffer[int]) interface{} { _, ok := b.Pop(); return ok },
+			},
+			want: []interface{}{0, false, false},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			b := robin.NewSliceBuffer[int]()
+			var got []interface{}
+			for _, op := range tc.operations {
+				got = append(got, op(b))
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Test %q failed: got %v, want %v", tc.name, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package robin
+
+type sliceNode[T comparable] struct {
+	v    T
+	prev int
+	next int
+}
+
+// SliceRobin is a round-robin data structure with the same semantics
+// as [Robin], but with its nodes stored contiguously in a slice and
+// linked by index rather than by pointer. This gives better cache
+// locality for large robins that are mostly stable and where
+// [SliceRobin.Next] dominates the workload.
+//
+// SliceRobin deliberately only offers the core API of [Robin]: it can
+// be unbounded, see [NewSliceRobin], or bounded with an optional
+// buffer, see [NewBoundedSliceRobin], and supports Add, Remove, Next,
+// Contains, BufferContains, Len, BufferLen and Reset. The options and
+// methods built on top of [Robin], such as hooks, serve limits and
+// cursor control, are not available.
+//
+// Removing a value moves the last node of the slice into the freed
+// slot, so the slice stays compact and all operations remain O(1),
+// or O(n) for variadic operations where n is the number of arguments.
+//
+// SliceRobin is not thread-safe.
+type SliceRobin[T comparable] struct {
+	nodes []sliceNode[T]
+	index map[T]int
+	next  int
+
+	maxLen int
+	buffer Buffer[T]
+}
+
+// NewSliceRobin creates a new unbounded [SliceRobin].
+func NewSliceRobin[T comparable]() *SliceRobin[T] {
+	return &SliceRobin[T]{index: make(map[T]int), next: -1}
+}
+
+type SliceRobinOption[T comparable] func(*SliceRobin[T])
+
+// WithSliceBuffer sets the buffer for a bounded [SliceRobin], with the
+// same semantics as [WithBuffer] for a bounded [Robin].
+func WithSliceBuffer[T comparable](buffer Buffer[T]) SliceRobinOption[T] {
+	return func(r *SliceRobin[T]) {
+		r.buffer = buffer
+	}
+}
+
+// NewBoundedSliceRobin creates a new bounded [SliceRobin] with a
+// maximum length. An optional buffer can be provided with the
+// [WithSliceBuffer] option. If the length is negative or zero, an
+// unbounded [SliceRobin] will be returned and any option will be
+// ignored.
+func NewBoundedSliceRobin[T comparable](len int, options ...SliceRobinOption[T]) *SliceRobin[T] {
+	if len <= 0 {
+		return NewSliceRobin[T]()
+	}
+	r := &SliceRobin[T]{
+		nodes:  make([]sliceNode[T], 0, len),
+		index:  make(map[T]int, len),
+		next:   -1,
+		maxLen: len,
+	}
+	for _, option := range options {
+		option(r)
+	}
+	return r
+}
+
+// attach added nodes to the circular list between the next node and
+// its predecessor and update next node to the new head
+func (r *SliceRobin[T]) attach(head, tail int) {
+	if head < 0 {
+		return
+	}
+
+	if r.next < 0 {
+		r.nodes[head].prev = tail
+		r.nodes[tail].next = head
+		r.next = head
+		return
+	}
+
+	prev := r.nodes[r.next].prev
+	next := r.next
+	r.nodes[head].prev = prev
+	r.nodes[tail].next = next
+	r.nodes[prev].next = head
+	r.nodes[next].prev = tail
+	r.next = head
+}
+
+// Add values to the robin between current position. A subsequent
+// call to [SliceRobin.Next] will return the first added value.
+// If the robin is bounded and full and a buffer is provided, the
+// values are pushed to the buffer, otherwise they are ignored.
+// Values already in the robin or in the buffer are ignored.
+func (r *SliceRobin[T]) Add(vs ...T) {
+	if r.maxLen > 0 && len(r.nodes) == r.maxLen && r.buffer == nil {
+		return
+	}
+
+	head, tail := -1, -1
+	for _, v := range vs {
+		if _, ok := r.index[v]; ok {
+			continue
+		}
+		if r.maxLen > 0 && len(r.nodes) == r.maxLen {
+			if r.buffer == nil {
+				break
+			}
+			if !r.buffer.Contains(v) {
+				r.buffer.Push(v)
+			}
+			continue
+		}
+		i := len(r.nodes)
+		r.nodes = append(r.nodes, sliceNode[T]{v: v, prev: tail, next: -1})
+		r.index[v] = i
+		if head < 0 {
+			head = i
+		} else {
+			r.nodes[tail].next = i
+		}
+		tail = i
+	}
+
+	r.attach(head, tail)
+}
+
+// removes a node from the circular list
+func (r *SliceRobin[T]) unlink(i int) {
+	// reset if removed value was the last
+	if r.nodes[i].next == i {
+		r.next = -1
+		return
+	}
+
+	prev, next := r.nodes[i].prev, r.nodes[i].next
+	r.nodes[prev].next = next
+	r.nodes[next].prev = prev
+
+	// advance robin if removed value belonged to next node
+	if r.next == i {
+		r.next = next
+	}
+}
+
+// moves the last node of the slice into the freed slot i and shrinks
+// the slice, keeping the nodes contiguous
+func (r *SliceRobin[T]) compact(i int) {
+	last := len(r.nodes) - 1
+	if i != last {
+		n := r.nodes[last]
+		if n.prev == last {
+			n.prev = i
+		}
+		if n.next == last {
+			n.next = i
+		}
+		r.nodes[i] = n
+		r.nodes[n.prev].next = i
+		r.nodes[n.next].prev = i
+		r.index[n.v] = i
+		if r.next == last {
+			r.next = i
+		}
+	}
+	r.nodes[last] = sliceNode[T]{}
+	r.nodes = r.nodes[:last]
+}
+
+// replaces a removed value with a value from the buffer if possible;
+// if not, the node has to be unlinked from the robin
+func (r *SliceRobin[T]) replaceValue(i int) bool {
+	if r.buffer != nil {
+		if v, ok := r.buffer.Pop(); ok {
+			r.nodes[i].v = v
+			r.index[v] = i
+			return true
+		}
+	}
+	return false
+}
+
+// Remove values from the robin. If the robin is bounded and there is a
+// non-empty buffer, each removed value will be replaced by popping a
+// value from the buffer. Values not in the robin, including values in
+// the buffer, are ignored.
+func (r *SliceRobin[T]) Remove(vs ...T) {
+	for _, v := range vs {
+		if i, ok := r.index[v]; ok {
+			delete(r.index, v)
+			if !r.replaceValue(i) {
+				r.unlink(i)
+				r.compact(i)
+			}
+		}
+	}
+}
+
+// Next returns the next value in the robin. If the robin is empty, the
+// second return value is false.
+func (r *SliceRobin[T]) Next() (T, bool) {
+	if r.next < 0 {
+		return *new(T), false
+	}
+	n := &r.nodes[r.next]
+	r.next = n.next
+	return n.v, true
+}
+
+// Contains returns true if the value is in the robin.
+func (r *SliceRobin[T]) Contains(v T) bool {
+	_, ok := r.index[v]
+	return ok
+}
+
+// BufferContains returns true if the value is in the buffer.
+// If there is no buffer, false is returned.
+func (r *SliceRobin[T]) BufferContains(v T) bool {
+	if r.buffer == nil {
+		return false
+	}
+	return r.buffer.Contains(v)
+}
+
+// Len returns the number of values in the robin.
+func (r *SliceRobin[T]) Len() int {
+	return len(r.nodes)
+}
+
+// BufferLen returns the number of values in the buffer.
+// If there is no buffer, 0 is returned.
+func (r *SliceRobin[T]) BufferLen() int {
+	if r.buffer == nil {
+		return 0
+	}
+	return r.buffer.Len()
+}
+
+// Reset the robin. If there is a buffer, it is reset as well.
+func (r *SliceRobin[T]) Reset() {
+	r.next = -1
+	if r.buffer == nil {
+		r.nodes = nil
+		r.index = make(map[T]int)
+		return
+	}
+	r.buffer.Reset()
+	r.nodes = make([]sliceNode[T], 0, r.maxLen)
+	r.index = make(map[T]int, r.maxLen)
+}
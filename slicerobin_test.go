@@ -0,0 +1,143 @@
+package robin_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/embeage/robin"
+)
+
+func TestSliceRobin(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxLen     int
+		options    []robin.SliceRobinOption[int]
+		operations []func(*robin.SliceRobin[int]) interface{}
+		want       []interface{}
+	}{
+		{
+			name: "basic round-robin",
+			operations: []func(*robin.SliceRobin[int]) interface{}{
+				func(r *robin.SliceRobin[int]) interface{} { r.Add(1, 2, 3); v, _ := r.Next(); return v },
+				func(r *robin.SliceRobin[int]) interface{} { v, _ := r.Next(); return v },
+				func(r *robin.SliceRobin[int]) interface{} { r.Add(4); v, _ := r.Next(); return v },
+				func(r *robin.SliceRobin[int]) interface{} { v, _ := r.Next(); return v },
+				func(r *robin.SliceRobin[int]) interface{} { v, _ := r.Next(); return v },
+				func(r *robin.SliceRobin[int]) interface{} { v, _ := r.Next(); return v },
+			},
+			want: []interface{}{1, 2, 4, 3, 1, 2},
+		},
+		{
+			name: "removing from robin and next on empty robin",
+			operations: []func(*robin.SliceRobin[int]) interface{}{
+				func(r *robin.SliceRobin[int]) interface{} { r.Add(1, 2, 3); r.Remove(1); v, _ := r.Next(); return v },
+				func(r *robin.SliceRobin[int]) interface{} { r.Remove(2); v, _ := r.Next(); return v },
+				func(r *robin.SliceRobin[int]) interface{} { v, _ := r.Next(); return v },
+				func(r *robin.SliceRobin[int]) interface{} { r.Remove(1, 3); _, ok := r.Next(); return ok },
+			},
+			want: []interface{}{2, 3, 3, false},
+		},
+		{
+			name: "removing should keep rotation order when compacting",
+			operations: []func(*robin.SliceRobin[int]) interface{}{
+				func(r *robin.SliceRobin[int]) interface{} {
+					r.Add(1, 2, 3, 4, 5)
+					r.Remove(2)
+					v, _ := r.Next()
+					return v
+				},
+				func(r *robin.SliceRobin[int]) interface{} { r.Remove(1); v, _ := r.Next(); return v },
+				func(r *robin.SliceRobin[int]) interface{} { v, _ := r.Next(); return v },
+				func(r *robin.SliceRobin[int]) interface{} { v, _ := r.Next(); return v },
+				func(r *robin.SliceRobin[int]) interface{} { return r.Len() },
+			},
+			want: []interface{}{1, 3, 4, 5, 3},
+		},
+		{
+			name: "duplicates should be ignored",
+			operations: []func(*robin.SliceRobin[int]) interface{}{
+				func(r *robin.SliceRobin[int]) interface{} { r.Add(1, 2, 3); return r.Len() },
+				func(r *robin.SliceRobin[int]) interface{} { r.Add(1, 2, 3); return r.Len() },
+				func(r *robin.SliceRobin[int]) interface{} { r.Remove(3); return r.Contains(3) },
+				func(r *robin.SliceRobin[int]) interface{} { return r.Contains(1) },
+			},
+			want: []interface{}{3, 3, false, true},
+		},
+		{
+			name: "basic reset",
+			operations: []func(*robin.SliceRobin[int]) interface{}{
+				func(r *robin.SliceRobin[int]) interface{} { r.Add(1, 2, 3); r.Reset(); return r.Len() },
+				func(r *robin.SliceRobin[int]) interface{} { _, ok := r.Next(); return ok },
+				func(r *robin.SliceRobin[int]) interface{} { r.Add(4); v, _ := r.Next(); return v },
+			},
+			want: []interface{}{0, false, 4},
+		},
+		{
+			name:   "bounded robin should ignore values when full",
+			maxLen: 2,
+			operations: []func(*robin.SliceRobin[int]) interface{}{
+				func(r *robin.SliceRobin[int]) interface{} { r.Add(1, 2, 3); return r.Len() },
+				func(r *robin.SliceRobin[int]) interface{} { return r.Contains(3) },
+				func(r *robin.SliceRobin[int]) interface{} { r.Remove(1); r.Add(3); return r.Contains(3) },
+				func(r *robin.SliceRobin[int]) interface{} { return r.BufferLen() },
+			},
+			want: []interface{}{2, false, true, 0},
+		},
+		{
+			name:    "buffer should replace removed values",
+			maxLen:  2,
+			options: []robin.SliceRobinOption[int]{robin.WithSliceBuffer[int](robin.NewLIFOBuffer[int](2))},
+			operations: []func(*robin.SliceRobin[int]) interface{}{
+				func(r *robin.SliceRobin[int]) interface{} { r.Add(1, 2, 3, 4, 3); return r.BufferLen() },
+				func(r *robin.SliceRobin[int]) interface{} { return r.BufferContains(4) },
+				func(r *robin.SliceRobin[int]) interface{} { r.Remove(1); return r.Len() },
+				func(r *robin.SliceRobin[int]) interface{} { v, _ := r.Next(); return v },
+				func(r *robin.SliceRobin[int]) interface{} { v, _ := r.Next(); return v },
+				func(r *robin.SliceRobin[int]) interface{} { r.Remove(2, 4); v, _ := r.Next(); return v },
+				func(r *robin.SliceRobin[int]) interface{} { return r.Len() },
+				func(r *robin.SliceRobin[int]) interface{} { r.Add(5, 6); r.Reset(); return r.BufferLen() },
+			},
+			want: []interface{}{2, true, 2, 4, 2, 3, 1, 0},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := robin.NewBoundedSliceRobin(tc.maxLen, tc.options...)
+			var got []interface{}
+			for _, op := range tc.operations {
+				got = append(got, op(r))
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Test %q failed: got %v, want %v", tc.name, got, tc.want)
+			}
+		})
+	}
+}
+
+func BenchmarkNext(b *testing.B) {
+	const n = 10000
+	vs := make([]int, n)
+	for i := range vs {
+		vs[i] = i
+	}
+
+	b.Run("Robin", func(b *testing.B) {
+		r := robin.NewUnbounded[int]()
+		r.Add(vs...)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			r.Next()
+		}
+	})
+
+	b.Run("SliceRobin", func(b *testing.B) {
+		r := robin.NewSliceRobin[int]()
+		r.Add(vs...)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			r.Next()
+		}
+	})
+}
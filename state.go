@@ -0,0 +1,103 @@
+package robin
+
+import "fmt"
+
+// State is a plain copy of the values and position of a [Robin], see
+// [Robin.Export] and [Robin.Import].
+type State[T comparable] struct {
+	// Values are the values of the robin in rotation order, starting
+	// from the oldest value.
+	Values []T
+	// CursorIndex is the index in Values of the value a subsequent
+	// call to Next would return, or -1 if there are no values.
+	CursorIndex int
+	// MaxLen is the maximum length of the robin, or 0 if unbounded.
+	MaxLen int
+	// BufferValues are the values of the buffer in the order they
+	// would be popped. It is nil if there is no buffer or the buffer
+	// cannot list its values.
+	BufferValues []T
+}
+
+// Export returns the [State] of the robin. Per-value state such as
+// serve counts is not included. It is O(n).
+func (r *Robin[T]) Export() State[T] {
+	s := State[T]{CursorIndex: -1, MaxLen: r.maxLen}
+	if r.oldest != nil {
+		s.Values = make([]T, 0, len(r.nodes))
+		node := r.oldest
+		for {
+			if node == r.next {
+				s.CursorIndex = len(s.Values)
+			}
+			s.Values = append(s.Values, node.v)
+			node = r.step(node)
+			if node == r.oldest {
+				break
+			}
+		}
+	}
+	if b, ok := r.buffer.(listingBuffer[T]); ok {
+		s.BufferValues = b.values()
+	}
+	return s
+}
+
+// Import resets the robin and restores a [State] returned by
+// [Robin.Export]: its maximum length, its values in the same rotation
+// order, its current position and the buffered values. The values are
+// restored as they are, without the checks applied by [Add] such as
+// [WithValidator]. The other options of the robin are kept, except
+// that the buffer is no longer used if the state is unbounded.
+//
+// It returns an error wrapping [ErrInvalidState], leaving the robin
+// unchanged, if the state cannot be restored as it is: if it has
+// duplicate values, more values than its maximum length, a cursor
+// index out of range, or buffered values the buffer of the robin
+// cannot hold.
+func (r *Robin[T]) Import(s State[T]) error {
+	if err := r.checkState(s); err != nil {
+		return err
+	}
+	r.Reset()
+	r.maxLen = s.MaxLen
+	if r.maxLen <= 0 {
+		r.buffer = nil
+	}
+	r.restore(s.Values)
+	for i := 0; i < s.CursorIndex; i++ {
+		r.next = r.step(r.next)
+	}
+	for i := len(s.BufferValues) - 1; i >= 0; i-- {
+		r.buffer.Push(s.BufferValues[i])
+	}
+	return nil
+}
+
+// checkState returns an error if the state cannot be imported as it is
+func (r *Robin[T]) checkState(s State[T]) error {
+	if s.MaxLen > 0 && len(s.Values) > s.MaxLen {
+		return fmt.Errorf("%w: %d values exceed maximum length %d", ErrInvalidState, len(s.Values), s.MaxLen)
+	}
+	if len(s.Values) == 0 && s.CursorIndex != -1 || len(s.Values) > 0 && (s.CursorIndex < 0 || s.CursorIndex >= len(s.Values)) {
+		return fmt.Errorf("%w: cursor index %d out of range", ErrInvalidState, s.CursorIndex)
+	}
+	if len(s.BufferValues) > 0 {
+		if s.MaxLen <= 0 || r.buffer == nil {
+			return fmt.Errorf("%w: buffered values without a buffer", ErrInvalidState)
+		}
+		if b, ok := r.buffer.(cappedBuffer[T]); ok && len(s.BufferValues) > b.Cap() {
+			return fmt.Errorf("%w: %d buffered values exceed buffer capacity %d", ErrInvalidState, len(s.BufferValues), b.Cap())
+		}
+	}
+	seen := make(map[T]struct{}, len(s.Values)+len(s.BufferValues))
+	for _, vs := range [][]T{s.Values, s.BufferValues} {
+		for _, v := range vs {
+			if _, ok := seen[v]; ok {
+				return fmt.Errorf("%w: duplicate value %v", ErrInvalidState, v)
+			}
+			seen[v] = struct{}{}
+		}
+	}
+	return nil
+}
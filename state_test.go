@@ -0,0 +1,119 @@
+package robin_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/embeage/robin"
+)
+
+func TestExportImport(t *testing.T) {
+	r := robin.NewBounded(3, robin.WithBuffer[int](robin.NewLIFOBuffer[int](3)))
+	r.Add(1, 2, 3, 4, 5)
+	r.Next()
+
+	s := r.Export()
+	want := robin.State[int]{
+		Values:       []int{1, 2, 3},
+		CursorIndex:  1,
+		MaxLen:       3,
+		BufferValues: []int{5, 4},
+	}
+	if !reflect.DeepEqual(s, want) {
+		t.Fatalf("Export got %+v, want %+v", s, want)
+	}
+
+	r.Remove(1, 2)
+	r.Add(6, 7)
+	next(r, 2)
+	if err := r.Import(s); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if got := r.Export(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Export after Import got %+v, want %+v", got, want)
+	}
+	if got, want := next(r, 4), []int{2, 3, 1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	r.Remove(2)
+	if !r.Contains(5) {
+		t.Errorf("Import should restore the buffer order")
+	}
+}
+
+func TestExportImportEmpty(t *testing.T) {
+	r := robin.NewUnbounded[int]()
+	s := r.Export()
+	if want := (robin.State[int]{CursorIndex: -1}); !reflect.DeepEqual(s, want) {
+		t.Errorf("Export got %+v, want %+v", s, want)
+	}
+
+	r.Add(1, 2)
+	if err := r.Import(s); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if r.Len() != 0 {
+		t.Errorf("Import of empty state got Len %v, want 0", r.Len())
+	}
+}
+
+func TestImportBypassesAddChecks(t *testing.T) {
+	s := robin.State[int]{Values: []int{1, 2, 3}, CursorIndex: 2}
+	r := robin.NewUnbounded(robin.WithValidator(func(v int) error {
+		if v == 2 {
+			return errors.New("no twos")
+		}
+		return nil
+	}))
+	if err := r.Import(s); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if got := r.Export(); !reflect.DeepEqual(got, s) {
+		t.Errorf("Export after Import got %+v, want %+v", got, s)
+	}
+	if got, want := next(r, 3), []int{3, 1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestImportUnboundedDropsBuffer(t *testing.T) {
+	r := robin.NewBounded(1, robin.WithBuffer[int](robin.NewLIFOBuffer[int](2)))
+	r.Add(1, 2)
+	if err := r.Import(robin.State[int]{Values: []int{3, 4}, CursorIndex: 0}); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if r.BufferLen() != 0 || r.BufferCap() != 0 {
+		t.Errorf("unbounded Import should not use the buffer")
+	}
+	r.Add(5)
+	if r.Len() != 3 {
+		t.Errorf("Len got %v, want 3", r.Len())
+	}
+}
+
+func TestImportInvalidState(t *testing.T) {
+	tests := []struct {
+		name  string
+		state robin.State[int]
+	}{
+		{"duplicate values", robin.State[int]{Values: []int{1, 1}, CursorIndex: 0}},
+		{"too many values", robin.State[int]{Values: []int{1, 2}, CursorIndex: 0, MaxLen: 1}},
+		{"cursor out of range", robin.State[int]{Values: []int{1}, CursorIndex: 1}},
+		{"buffer without room", robin.State[int]{Values: []int{1}, MaxLen: 1, BufferValues: []int{2, 3}}},
+		{"buffered duplicate", robin.State[int]{Values: []int{1}, MaxLen: 1, BufferValues: []int{1}}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := robin.NewBounded(1, robin.WithBuffer[int](robin.NewLIFOBuffer[int](1)))
+			r.Add(9)
+			if err := r.Import(tc.state); !errors.Is(err, robin.ErrInvalidState) {
+				t.Errorf("Import got %v, want %v", err, robin.ErrInvalidState)
+			}
+			if !r.Contains(9) {
+				t.Errorf("failed Import should leave the robin unchanged")
+			}
+		})
+	}
+}
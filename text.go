@@ -0,0 +1,147 @@
+package robin
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// WithParser sets the function used by [Robin.ReadFrom] to parse
+// values. It is only needed for types other than strings and the
+// built-in integer and floating-point types.
+func WithParser[T comparable](parse func(string) (T, error)) BoundedOption[T] {
+	return func(r *Robin[T]) {
+		r.parse = parse
+	}
+}
+
+// WriteTo writes the robin in a human-readable text format: a header
+// line with the maximum length and the buffer length, followed by one
+// line per value in rotation order from the current position, formatted
+// with fmt. Buffered values are not written. Values must not contain
+// newlines for [Robin.ReadFrom] to read them back. It implements
+// [io.WriterTo].
+func (r *Robin[T]) WriteTo(w io.Writer) (int64, error) {
+	bw := bufio.NewWriter(w)
+	var written int64
+	n, err := fmt.Fprintf(bw, "robin maxLen=%d buffer=%d\n", r.maxLen, r.BufferLen())
+	written += int64(n)
+	if err != nil {
+		return written, err
+	}
+	for _, v := range r.values() {
+		n, err := fmt.Fprintln(bw, v)
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, bw.Flush()
+}
+
+// ReadFrom restores the maximum length and the values written by
+// [Robin.WriteTo] like [Robin.Import], so that the values are returned
+// by [Next] in the order they were written. The buffer length in the
+// header is informational only, as buffered values are not written.
+// Lines may end in "\n" or "\r\n". Strings and the built-in integer
+// and floating-point types are parsed by default, other types need
+// [WithParser], otherwise [ErrNoParser] is returned. It implements
+// [io.ReaderFrom].
+func (r *Robin[T]) ReadFrom(rd io.Reader) (int64, error) {
+	cr := &countingReader{r: rd}
+	s := bufio.NewScanner(cr)
+	if !s.Scan() {
+		if err := s.Err(); err != nil {
+			return cr.n, err
+		}
+		return cr.n, io.ErrUnexpectedEOF
+	}
+	var maxLen, bufferLen int
+	if _, err := fmt.Sscanf(s.Text(), "robin maxLen=%d buffer=%d", &maxLen, &bufferLen); err != nil {
+		return cr.n, fmt.Errorf("robin: invalid header: %w", err)
+	}
+
+	var vs []T
+	for s.Scan() {
+		v, err := r.parseValue(s.Text())
+		if err != nil {
+			return cr.n, err
+		}
+		vs = append(vs, v)
+	}
+	if err := s.Err(); err != nil {
+		return cr.n, err
+	}
+	state := State[T]{MaxLen: maxLen, Values: vs, CursorIndex: -1}
+	if len(vs) > 0 {
+		state.CursorIndex = 0
+	}
+	return cr.n, r.Import(state)
+}
+
+// countingReader counts the bytes read from a reader, see
+// [Robin.ReadFrom]
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// parseValue parses a value written by [Robin.WriteTo]
+func (r *Robin[T]) parseValue(s string) (T, error) {
+	if r.parse != nil {
+		return r.parse(s)
+	}
+	var (
+		v   any
+		err error
+	)
+	switch any(*new(T)).(type) {
+	case string:
+		v = s
+	case int:
+		v, err = strconv.Atoi(s)
+	case int8:
+		n, e := strconv.ParseInt(s, 10, 8)
+		v, err = int8(n), e
+	case int16:
+		n, e := strconv.ParseInt(s, 10, 16)
+		v, err = int16(n), e
+	case int32:
+		n, e := strconv.ParseInt(s, 10, 32)
+		v, err = int32(n), e
+	case int64:
+		v, err = strconv.ParseInt(s, 10, 64)
+	case uint:
+		n, e := strconv.ParseUint(s, 10, 0)
+		v, err = uint(n), e
+	case uint8:
+		n, e := strconv.ParseUint(s, 10, 8)
+		v, err = uint8(n), e
+	case uint16:
+		n, e := strconv.ParseUint(s, 10, 16)
+		v, err = uint16(n), e
+	case uint32:
+		n, e := strconv.ParseUint(s, 10, 32)
+		v, err = uint32(n), e
+	case uint64:
+		v, err = strconv.ParseUint(s, 10, 64)
+	case float32:
+		n, e := strconv.ParseFloat(s, 32)
+		v, err = float32(n), e
+	case float64:
+		v, err = strconv.ParseFloat(s, 64)
+	default:
+		return *new(T), ErrNoParser
+	}
+	if err != nil {
+		return *new(T), err
+	}
+	return v.(T), nil
+}
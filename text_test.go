@@ -0,0 +1,110 @@
+package robin_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/embeage/robin"
+)
+
+func TestWriteToReadFrom(t *testing.T) {
+	r := robin.NewBounded(3, robin.WithBuffer[int](robin.NewLIFOBuffer[int](2)))
+	r.Add(1, 2, 3, 4)
+	r.Next()
+
+	var buf bytes.Buffer
+	n, err := r.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("WriteTo failed: %v", err)
+	}
+	if want := "robin maxLen=3 buffer=1\n2\n3\n1\n"; buf.String() != want {
+		t.Errorf("WriteTo wrote %q, want %q", buf.String(), want)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("WriteTo returned %d, wrote %d bytes", n, buf.Len())
+	}
+
+	got := robin.NewUnbounded[int]()
+	got.Add(9)
+	size := int64(buf.Len())
+	if n, err := got.ReadFrom(&buf); err != nil || n != size {
+		t.Fatalf("ReadFrom got %d, %v, want %d, nil", n, err, size)
+	}
+	if got.Len() != 3 || !got.IsFull() {
+		t.Errorf("Len after ReadFrom got %d, full %v, want 3, true", got.Len(), got.IsFull())
+	}
+	if vs, want := next(got, 3), []int{2, 3, 1}; !reflect.DeepEqual(vs, want) {
+		t.Errorf("got %v, want %v", vs, want)
+	}
+}
+
+func TestReadFromCRLF(t *testing.T) {
+	text := "robin maxLen=0 buffer=0\r\n2\r\n3\r\n1"
+	r := robin.NewBounded[int](1)
+	if n, err := r.ReadFrom(strings.NewReader(text)); err != nil || n != int64(len(text)) {
+		t.Fatalf("ReadFrom got %d, %v, want %d, nil", n, err, len(text))
+	}
+	if r.IsFull() {
+		t.Errorf("ReadFrom should apply the unbounded maximum length")
+	}
+	if vs, want := next(r, 3), []int{2, 3, 1}; !reflect.DeepEqual(vs, want) {
+		t.Errorf("got %v, want %v", vs, want)
+	}
+}
+
+func roundTrip[T comparable](t *testing.T, vs ...T) {
+	t.Helper()
+	r := robin.NewUnbounded[T]()
+	r.Add(vs...)
+
+	var buf bytes.Buffer
+	if _, err := r.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo failed: %v", err)
+	}
+	got := robin.NewUnbounded[T]()
+	if _, err := got.ReadFrom(&buf); err != nil {
+		t.Fatalf("ReadFrom %T failed: %v", *new(T), err)
+	}
+	for _, want := range vs {
+		if v, _ := got.Next(); v != want {
+			t.Errorf("Next got %v, want %v", v, want)
+		}
+	}
+}
+
+func TestReadFromNumericTypes(t *testing.T) {
+	roundTrip[int8](t, -8, 7)
+	roundTrip[int16](t, -16, 15)
+	roundTrip[int32](t, -32, 31)
+	roundTrip[uint](t, 0, 1)
+	roundTrip[uint8](t, 8, 255)
+	roundTrip[uint16](t, 16, 65535)
+	roundTrip[uint32](t, 32, 1<<32-1)
+	roundTrip[float32](t, 1.5, -0.25)
+	roundTrip(t, "a", "b c")
+}
+
+func TestReadFromParser(t *testing.T) {
+	type point struct{ x, y int }
+	text := "robin maxLen=0 buffer=0\n1,2\n3,4\n"
+
+	if _, err := robin.NewUnbounded[point]().ReadFrom(strings.NewReader(text)); !errors.Is(err, robin.ErrNoParser) {
+		t.Errorf("ReadFrom without parser got %v, want %v", err, robin.ErrNoParser)
+	}
+
+	r := robin.NewUnbounded(robin.WithParser(func(s string) (point, error) {
+		var p point
+		_, err := fmt.Sscanf(s, "%d,%d", &p.x, &p.y)
+		return p, err
+	}))
+	if _, err := r.ReadFrom(strings.NewReader(text)); err != nil {
+		t.Fatalf("ReadFrom failed: %v", err)
+	}
+	if v, _ := r.Next(); v != (point{1, 2}) {
+		t.Errorf("Next got %v, want {1 2}", v)
+	}
+}
@@ -0,0 +1,25 @@
+package robin
+
+// NewStringRobin creates a new unbounded [Robin] of strings. It is the
+// same as NewUnbounded[string].
+func NewStringRobin(options ...BoundedOption[string]) *Robin[string] {
+	return NewUnbounded(options...)
+}
+
+// NewIntRobin creates a new unbounded [Robin] of ints. It is the same
+// as NewUnbounded[int].
+func NewIntRobin(options ...BoundedOption[int]) *Robin[int] {
+	return NewUnbounded(options...)
+}
+
+// NewStringBuffer creates a new [LIFOBuffer] of strings with the given
+// capacity. It is the same as NewLIFOBuffer[string].
+func NewStringBuffer(capacity int, options ...LIFOBufferOption[string]) *LIFOBuffer[string] {
+	return NewLIFOBuffer(capacity, options...)
+}
+
+// NewIntBuffer creates a new [LIFOBuffer] of ints with the given
+// capacity. It is the same as NewLIFOBuffer[int].
+func NewIntBuffer(capacity int, options ...LIFOBufferOption[int]) *LIFOBuffer[int] {
+	return NewLIFOBuffer(capacity, options...)
+}
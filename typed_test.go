@@ -0,0 +1,37 @@
+package robin_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/embeage/robin"
+)
+
+func TestTypedConstructors(t *testing.T) {
+	s := robin.NewStringRobin()
+	s.Add("a", "b")
+	if v, _ := s.Next(); v != "a" {
+		t.Errorf("NewStringRobin Next got %q, want %q", v, "a")
+	}
+
+	i := robin.NewIntRobin(robin.WithMaxServes[int](1))
+	i.Add(1, 2)
+	if got, want := next(i, 3), []int{1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("NewIntRobin got %v, want %v", got, want)
+	}
+
+	sb := robin.NewStringBuffer(1)
+	sb.Push("a")
+	sb.Push("b")
+	if v, _ := sb.Pop(); v != "b" || sb.Len() != 0 {
+		t.Errorf("NewStringBuffer Pop got %q, want %q", v, "b")
+	}
+
+	ib := robin.NewIntBuffer(2, robin.WithRejectOnFull[int]())
+	ib.Push(1)
+	ib.Push(2)
+	ib.Push(3)
+	if ib.Contains(3) || ib.Cap() != 2 {
+		t.Errorf("NewIntBuffer should pass options to the buffer")
+	}
+}
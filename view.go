@@ -0,0 +1,67 @@
+package robin
+
+// RobinView is a read-only snapshot of a [Robin], see [Robin.Snapshot].
+// It holds a copy of the values in rotation order, so later changes to
+// the robin do not affect it, and it has its own current position
+// that starts where the robin's was when the snapshot was taken.
+//
+// All operations are O(1), except [RobinView.ToSlice] which is O(n).
+// Only [RobinView.Next] modifies the view, so the other methods may be
+// called concurrently as long as Next is not.
+type RobinView[T comparable] struct {
+	vs []T
+	i  int
+}
+
+// Snapshot returns a [RobinView] of the current values and position
+// of the robin. It is O(n).
+func (r *Robin[T]) Snapshot() *RobinView[T] {
+	return &RobinView[T]{vs: r.values()}
+}
+
+// Next returns the next value in the view. If the view is empty, the
+// second return value is false.
+func (w *RobinView[T]) Next() (T, bool) {
+	if len(w.vs) == 0 {
+		return *new(T), false
+	}
+	v := w.vs[w.i]
+	w.i = (w.i + 1) % len(w.vs)
+	return v, true
+}
+
+// Peek returns the value a subsequent call to [RobinView.Next] would
+// return. If the view is empty, the second return value is false.
+func (w *RobinView[T]) Peek() (T, bool) {
+	if len(w.vs) == 0 {
+		return *new(T), false
+	}
+	return w.vs[w.i], true
+}
+
+// Len returns the number of values in the view.
+func (w *RobinView[T]) Len() int {
+	return len(w.vs)
+}
+
+// ToSlice returns the values of the view in rotation order, starting
+// from the current position.
+func (w *RobinView[T]) ToSlice() []T {
+	vs := make([]T, 0, len(w.vs))
+	vs = append(vs, w.vs[w.i:]...)
+	return append(vs, w.vs[:w.i]...)
+}
+
+// All returns an iterator over the values of the view in rotation
+// order from the position of the robin when the snapshot was taken,
+// regardless of the current position of the view. It does not modify
+// the view, so it may be used concurrently with any of its methods.
+func (w *RobinView[T]) All() func(yield func(T) bool) {
+	return func(yield func(T) bool) {
+		for _, v := range w.vs {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package robin_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/embeage/robin"
+)
+
+func TestRobinView(t *testing.T) {
+	r := robin.NewUnbounded[int]()
+	r.Add(1, 2, 3)
+	r.Next()
+
+	w := r.Snapshot()
+	r.Remove(2)
+	r.Add(4)
+	r.Next()
+
+	if got, want := w.Len(), 3; got != want {
+		t.Errorf("Len got %v, want %v", got, want)
+	}
+	if got, want := w.ToSlice(), []int{2, 3, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ToSlice got %v, want %v", got, want)
+	}
+	if v, ok := w.Peek(); !ok || v != 2 {
+		t.Errorf("Peek got %v, want 2", v)
+	}
+
+	var got []int
+	for i := 0; i < 4; i++ {
+		v, _ := w.Next()
+		got = append(got, v)
+	}
+	if want := []int{2, 3, 1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Next got %v, want %v", got, want)
+	}
+	if got, want := w.ToSlice(), []int{3, 1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ToSlice after Next got %v, want %v", got, want)
+	}
+	if got, want := next(r, 3), []int{3, 1, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("robin got %v, want %v", got, want)
+	}
+}
+
+func TestRobinViewEmpty(t *testing.T) {
+	w := robin.NewUnbounded[int]().Snapshot()
+	if _, ok := w.Next(); ok {
+		t.Errorf("Next on empty view returned true")
+	}
+	if _, ok := w.Peek(); ok {
+		t.Errorf("Peek on empty view returned true")
+	}
+	if got := w.ToSlice(); len(got) != 0 {
+		t.Errorf("ToSlice on empty view got %v", got)
+	}
+}
+
+func TestRobinViewAll(t *testing.T) {
+	r := robin.NewUnbounded[int]()
+	r.Add(1, 2, 3)
+	r.Next()
+
+	w := r.Snapshot()
+	w.Next()
+	done := make(chan []int)
+	go func() {
+		var got []int
+		w.All()(func(v int) bool {
+			got = append(got, v)
+			return true
+		})
+		done <- got
+	}()
+	for i := 4; i < 100; i++ {
+		r.Add(i)
+		r.Remove(i - 3)
+		r.Next()
+	}
+
+	if got, want := <-done, []int{2, 3, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("All got %v, want %v", got, want)
+	}
+
+	var got []int
+	w.All()(func(v int) bool {
+		got = append(got, v)
+		return len(got) < 2
+	})
+	if want := []int{2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("All with early stop got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,70 @@
+package robin
+
+// WithWFQ makes [Robin.Next] return values in weighted fair queuing
+// order instead of rotation order. Every value has a weight, 1 unless
+// set by [Robin.SetWeight], and a virtual finish time that grows by
+// 1/weight each time it is returned. Next returns the value with the
+// smallest finish time, preferring values in rotation order from the
+// current position on ties, so in the long run values are returned in
+// proportion to their weights. Next is O(n), and [WithMinGap] is
+// ignored.
+func WithWFQ[T comparable]() BoundedOption[T] {
+	return func(r *Robin[T]) {
+		r.wfq = true
+	}
+}
+
+// SetWeight sets the weight of a value for [WithWFQ] and restarts its
+// finish time from the current virtual time. It returns false if the
+// value is not in the robin, WithWFQ is not set, or the weight is not
+// positive.
+func (r *Robin[T]) SetWeight(v T, w float64) bool {
+	node, ok := r.nodes[v]
+	if !ok || !r.wfq || w <= 0 {
+		return false
+	}
+	node.ext.weight = w
+	node.ext.finish = r.virtual + 1/w
+	return true
+}
+
+// VirtualTime returns the virtual time of the robin, which is the
+// finish time of the value last returned by [Next], see [WithWFQ].
+func (r *Robin[T]) VirtualTime() float64 {
+	return r.virtual
+}
+
+// VirtualFinishTimes returns the virtual finish times of all values in
+// the robin, see [WithWFQ]. If WithWFQ is not set, nil is returned.
+func (r *Robin[T]) VirtualFinishTimes() map[T]float64 {
+	if !r.wfq {
+		return nil
+	}
+	times := make(map[T]float64, len(r.nodes))
+	for v, node := range r.nodes {
+		times[v] = node.ext.finish
+	}
+	return times
+}
+
+// pickWFQ returns the node with the smallest virtual finish time,
+// ignoring the nodes for which skip, if not nil, returns true, or nil
+// if there is none
+func (r *Robin[T]) pickWFQ(skip func(*node[T]) bool) *node[T] {
+	var picked *node[T]
+	node := r.next
+	for i := 0; i < len(r.nodes); i++ {
+		if (skip == nil || !skip(node)) && (picked == nil || node.ext.finish < picked.ext.finish) {
+			picked = node
+		}
+		node = r.step(node)
+	}
+	return picked
+}
+
+// advanceWFQ advances the virtual time to the finish time of a served
+// node and the finish time of the node by the inverse of its weight
+func (r *Robin[T]) advanceWFQ(node *node[T]) {
+	r.virtual = node.ext.finish
+	node.ext.finish += 1 / node.ext.weight
+}
@@ -0,0 +1,47 @@
+package robin_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/embeage/robin"
+)
+
+func TestWFQ(t *testing.T) {
+	r := robin.NewUnbounded(robin.WithWFQ[int](), robin.WithServeCounts[int]())
+	r.Add(1, 2, 3)
+	if r.SetWeight(4, 1) || r.SetWeight(1, 0) {
+		t.Errorf("SetWeight of absent value or non-positive weight returned true")
+	}
+	r.SetWeight(1, 1.5)
+	r.SetWeight(2, 0.5)
+
+	const n = 3000
+	next(r, n)
+	counts := r.ServeCounts()
+	// weights 1.5, 0.5 and 1 out of 3
+	for v, want := range map[int]float64{1: 0.5, 2: 1.0 / 6, 3: 1.0 / 3} {
+		if got := float64(counts[v]) / n; math.Abs(got-want) > 0.01 {
+			t.Errorf("value %d got share %.3f, want %.3f", v, got, want)
+		}
+	}
+	if got := r.VirtualTime(); math.Abs(got-n/3.0) > 1 {
+		t.Errorf("VirtualTime got %v, want about %v", got, n/3.0)
+	}
+}
+
+func TestWFQOrder(t *testing.T) {
+	r := robin.NewUnbounded(robin.WithWFQ[int]())
+	r.Add(1, 2)
+	r.SetWeight(1, 2)
+	if got, want := next(r, 6), []int{1, 2, 1, 1, 2, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got, want := r.VirtualFinishTimes(), map[int]float64{1: 2.5, 2: 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("VirtualFinishTimes got %v, want %v", got, want)
+	}
+	if robin.NewUnbounded[int]().VirtualFinishTimes() != nil {
+		t.Errorf("VirtualFinishTimes without WFQ should be nil")
+	}
+}